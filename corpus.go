@@ -0,0 +1,134 @@
+package streeng
+
+import "sort"
+
+/*
+Corpus is a struct of many documents which share one tree.
+Tokens of documents follow each other in words of the tree,
+so a word index is a posting of document and position
+*/
+type Corpus struct {
+	streeng *Streeng
+	docs    []document
+	ids     map[string]int
+	df      map[string]int
+	length  int
+	scoring Scoring
+	k1      float64
+	b       float64
+}
+
+type document struct {
+	id      string
+	start   int
+	length  int
+	removed bool
+}
+
+// Hit is a struct of positions of a query in a document
+type Hit struct {
+	Document  string
+	Positions []int
+}
+
+/*
+MakeCorpus makes an empty corpus. Options are options of
+MakeStreeng, but DAWG option is ignored since documents
+change the tree
+*/
+func MakeCorpus(opts ...Option) *Corpus {
+	c := new(Corpus)
+	c.streeng = MakeStreeng(nil, opts...)
+	c.streeng.dawg = false
+	c.ids = make(map[string]int)
+	c.df = make(map[string]int)
+	c.scoring, c.k1, c.b = BM25, 1.2, 0.75
+	return c
+}
+
+/*
+AddDocument function adds tokens of a document to the corpus.
+If there is a document which has the same id, it is replaced
+*/
+func (c *Corpus) AddDocument(id string, tokens []string) {
+	c.RemoveDocument(id)
+	doc := document{id: id, start: len(c.streeng.words), length: len(tokens)}
+	seen := make(map[string]bool)
+	for _, v := range tokens {
+		c.streeng.Add(v)
+		if !seen[v] {
+			seen[v] = true
+			c.df[v]++
+		}
+	}
+	c.length += len(tokens)
+	c.ids[id] = len(c.docs)
+	c.docs = append(c.docs, doc)
+}
+
+/*
+RemoveDocument function removes tokens of document from the tree.
+It returns false if there is not a document of id
+*/
+func (c *Corpus) RemoveDocument(id string) bool {
+	i, ok := c.ids[id]
+	if !ok {
+		return false
+	}
+	doc := &c.docs[i]
+	seen := make(map[string]bool)
+	for k := doc.start; k < doc.start+doc.length; k++ {
+		word := c.streeng.words[k]
+		if !seen[word] {
+			seen[word] = true
+			c.df[word]--
+			if c.df[word] == 0 {
+				delete(c.df, word)
+			}
+		}
+		c.streeng.Remove(k)
+	}
+	c.length -= doc.length
+	doc.removed = true
+	delete(c.ids, id)
+	return true
+}
+
+// Search function searches given word in documents
+func (c *Corpus) Search(word string) []Hit {
+	return c.hits(c.streeng.Search(word))
+}
+
+// StartWith function searches words which start with given string in documents
+func (c *Corpus) StartWith(word string) []Hit {
+	words := c.streeng.StartWith(word)
+	sort.Ints(words)
+	return c.hits(words)
+}
+
+// Len returns count of documents in the corpus
+func (c *Corpus) Len() int {
+	return len(c.ids)
+}
+
+// hits groups sorted word indexes by documents
+func (c *Corpus) hits(words []int) []Hit {
+	hits := []Hit{}
+	for _, v := range words {
+		i := c.document(v)
+		doc := c.docs[i]
+		if len(hits) == 0 || hits[len(hits)-1].Document != doc.id {
+			hits = append(hits, Hit{Document: doc.id})
+		}
+		hit := &hits[len(hits)-1]
+		hit.Positions = append(hit.Positions, v-doc.start)
+	}
+	return hits
+}
+
+// document returns index of document which has word of index
+func (c *Corpus) document(index int) int {
+	return sort.Search(len(c.docs), func(i int) bool {
+		return c.docs[i].start+c.docs[i].length > index
+	})
+}
@@ -0,0 +1,72 @@
+package streeng
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCorpus(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	chapters := strings.Split(text, "Chapter ")
+	corpus := MakeCorpus()
+	ids := []string{}
+	for k, v := range chapters {
+		id := fmt.Sprintf("chapter-%d", k)
+		ids = append(ids, id)
+		corpus.AddDocument(id, strings.Fields(v))
+	}
+	corpus.RemoveDocument(ids[3])
+	corpus.AddDocument(ids[5], strings.Fields(chapters[6]))
+	tests := []string{`Darcy`, `Mrs.`, `asd`, `Wickham`}
+	for _, test := range tests {
+		expected := []Hit{}
+		for k := range chapters {
+			if k == 3 || k == 5 {
+				continue
+			}
+			positions := []int{}
+			for i, word := range strings.Fields(chapters[k]) {
+				if word == test {
+					positions = append(positions, i)
+				}
+			}
+			if len(positions) > 0 {
+				expected = append(expected, Hit{ids[k], positions})
+			}
+		}
+		positions := []int{}
+		for i, word := range strings.Fields(chapters[6]) {
+			if word == test {
+				positions = append(positions, i)
+			}
+		}
+		if len(positions) > 0 {
+			expected = append(expected, Hit{ids[5], positions})
+		}
+		results := corpus.Search(test)
+		if reflect.DeepEqual(results, expected) {
+			t.Logf("Test Successful: word: %s", test)
+		} else {
+			t.Errorf("Test Fail:\t word: %s \t expected: %d \t result: %d",
+				test, len(expected), len(results))
+		}
+	}
+	i, j := 0, 0
+	for _, hit := range corpus.StartWith("Darc") {
+		i += len(hit.Positions)
+	}
+	for _, hit := range corpus.Search("Darcy") {
+		j += len(hit.Positions)
+	}
+	if i <= j {
+		t.Errorf("Test Fail:\t start with: %d \t search: %d", i, j)
+	}
+	if corpus.Len() != len(chapters)-1 || corpus.RemoveDocument(ids[3]) {
+		t.Errorf("Test Fail:\t len: %d", corpus.Len())
+	}
+}
@@ -0,0 +1,255 @@
+package streeng
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// dawgEdge is an edge whose child is not minimized yet
+type dawgEdge struct {
+	parent *Node
+	value  rune
+	child  *Node
+}
+
+// dawgBuilder builds minimal word graph of sorted terms
+type dawgBuilder struct {
+	root      *Node
+	register  map[string]*Node
+	ids       map[*Node]int
+	unchecked []dawgEdge
+	prev      []rune
+	trieCount int
+}
+
+/*
+buildDAWG builds minimal word graph of words which are not
+removed. Terminal nodes are shared by many terms, so word
+indexes can not be kept in nodes. They are kept in postings
+by rank of term, and rank of a term is found by sizes of nodes
+*/
+func buildDAWG(s *Streeng) {
+	groups := make(map[string][]int)
+	for k, v := range s.words {
+		if !s.removed[k] && len(v) > 0 {
+			key := string([]rune(v))
+			groups[key] = append(groups[key], k)
+		}
+	}
+	terms := make([]string, 0, len(groups))
+	for k := range groups {
+		terms = append(terms, k)
+	}
+	sort.Strings(terms)
+	b := new(dawgBuilder)
+	b.root = new(Node)
+	b.root.characters = make(map[rune]*Node)
+	b.register = make(map[string]*Node)
+	b.ids = make(map[*Node]int)
+	b.trieCount = 1
+	s.depth = 0
+	s.postings = make([][]int, len(terms))
+	for k, v := range terms {
+		runic := []rune(v)
+		if len(runic) > s.depth {
+			s.depth = len(runic)
+		}
+		b.insert(runic)
+		s.postings[k] = groups[v]
+	}
+	b.minimize(0)
+	dawgSize(b.root)
+	s.root = b.root
+	s.nodeCount = len(b.register) + 1
+	s.trieCount = b.trieCount
+	s.plain = nil
+}
+
+// insert adds runes which are bigger than previous runes
+func (b *dawgBuilder) insert(runic []rune) {
+	prefix := 0
+	for prefix < len(runic) && prefix < len(b.prev) && runic[prefix] == b.prev[prefix] {
+		prefix++
+	}
+	b.minimize(prefix)
+	tempNode := b.root
+	if len(b.unchecked) > 0 {
+		tempNode = b.unchecked[len(b.unchecked)-1].child
+	}
+	for _, v := range runic[prefix:] {
+		n := new(Node)
+		n.characters = make(map[rune]*Node)
+		n.value = v
+		tempNode.characters[v] = n
+		b.unchecked = append(b.unchecked, dawgEdge{tempNode, v, n})
+		tempNode = n
+		b.trieCount++
+	}
+	tempNode.final = true
+	b.prev = runic
+}
+
+/*
+minimize replaces unchecked children deeper than level with
+an equivalent registered node, or registers them
+*/
+func (b *dawgBuilder) minimize(level int) {
+	for i := len(b.unchecked) - 1; i >= level; i-- {
+		edge := b.unchecked[i]
+		key := b.signature(edge.child)
+		if val, ok := b.register[key]; ok {
+			edge.parent.characters[edge.value] = val
+		} else {
+			b.register[key] = edge.child
+			b.ids[edge.child] = len(b.ids)
+		}
+	}
+	b.unchecked = b.unchecked[:level]
+}
+
+// signature is equal for nodes which have the same right language
+func (b *dawgBuilder) signature(node *Node) string {
+	keys := []rune{}
+	for k := range node.characters {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	var sb strings.Builder
+	if node.final {
+		sb.WriteByte('!')
+	}
+	for _, v := range keys {
+		sb.WriteString(strconv.Itoa(int(v)))
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(b.ids[node.characters[v]]))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+// dawgSize calculates count of terms under each node once
+func dawgSize(node *Node) int {
+	if node.size == 0 {
+		if node.final {
+			node.size = 1
+		}
+		for _, v := range node.characters {
+			node.size += dawgSize(v)
+		}
+	}
+	return node.size
+}
+
+/*
+dawgRank walks runes from root and returns count of terms
+smaller than runes and the node of runes, or nil if
+runes are not in the graph
+*/
+func dawgRank(root *Node, runic []rune) (int, *Node) {
+	rank := 0
+	tempNode := root
+	for _, r := range runic {
+		if tempNode.final {
+			rank++
+		}
+		for k, v := range tempNode.characters {
+			if k < r {
+				rank += v.size
+			}
+		}
+		tempNode = tempNode.characters[r]
+		if tempNode == nil {
+			return 0, nil
+		}
+	}
+	return rank, tempNode
+}
+
+func dawgSearch(s *Streeng, runic []rune) []int {
+	rank, tempNode := dawgRank(s.root, runic)
+	if tempNode == nil || !tempNode.final {
+		return nil
+	}
+	return s.postings[rank]
+}
+
+func dawgStartWith(s *Streeng, runic []rune) []int {
+	rank, tempNode := dawgRank(s.root, runic)
+	if tempNode == nil {
+		return nil
+	}
+	words := []int{}
+	for _, v := range s.postings[rank : rank+tempNode.size] {
+		words = append(words, v...)
+	}
+	return words
+}
+
+/*
+dawgFoldWords returns words of terms at paths of fold cursors.
+If exact is false, words of terms which start with paths are returned
+*/
+func dawgFoldWords(s *Streeng, cursors []cursor, paths [][]rune, exact bool) []int {
+	words := []int{}
+	for k, c := range cursors {
+		if exact && c.node.final {
+			words = append(words, dawgSearch(s, paths[k])...)
+		} else if !exact {
+			words = append(words, dawgStartWith(s, paths[k])...)
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	sort.Ints(words)
+	return words
+}
+
+/*
+plainRoot returns root of the tree whose nodes keep words.
+Nodes of word graphs are shared by many terms, so a plain
+tree is made for them once, and it is kept until the tree
+is cleaned
+*/
+func plainRoot(s *Streeng) *Node {
+	if !s.dawg {
+		return s.root
+	}
+	s.lockLazy()
+	defer s.unlockLazy()
+	if s.plain == nil {
+		s.plain, _ = makeTree(s, false, false)
+	}
+	return s.plain
+}
+
+/*
+eachTerm calls fn for distinct terms and their words in order.
+Terms of word graphs are found in postings by rank.
+It returns false when fn returns false, so the walk stops
+*/
+func eachTerm(s *Streeng, fn func(term string, words []int) bool) bool {
+	if s.dawg {
+		for _, words := range s.postings {
+			if !fn(s.words[words[0]], words) {
+				return false
+			}
+		}
+		return true
+	}
+	return eachTermChild(s, s.root, fn)
+}
+
+// eachTermChild calls fn for terms under node in order
+func eachTermChild(s *Streeng, node *Node, fn func(string, []int) bool) bool {
+	if node != s.root && len(node.words) > 0 && !fn(s.words[node.words[0]], node.words) {
+		return false
+	}
+	for _, v := range sortedChildren(node) {
+		if !eachTermChild(s, v, fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package streeng
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestDAWG(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	dawg := MakeStreeng(words, DAWG())
+	if dawg.NodeCount() >= streeng.NodeCount() || dawg.MinimizationRate() <= 1 ||
+		dawg.Depth() != streeng.Depth() {
+		t.Errorf("Test Fail:\t dawg: %d %f \t trie: %d",
+			dawg.NodeCount(), dawg.MinimizationRate(), streeng.NodeCount())
+	} else {
+		t.Logf("Test Successful:\t dawg: %d %f \t trie: %d",
+			dawg.NodeCount(), dawg.MinimizationRate(), streeng.NodeCount())
+	}
+	sorted := func(v []int) []int {
+		v = append([]int{}, v...)
+		sort.Ints(v)
+		return v
+	}
+	tests := []string{`Mrs.`, `Mr`, `the`, `th`, `ness`, `disc`, `ion`,
+		`asd`, `daughte`, `w`, `Elizabeth`, `zeal`, `A`, ``}
+	for _, test := range tests {
+		if !reflect.DeepEqual(dawg.Search(test), streeng.Search(test)) ||
+			dawg.Contains(test) != streeng.Contains(test) ||
+			!reflect.DeepEqual(sorted(dawg.StartWith(test)), sorted(streeng.StartWith(test))) {
+			t.Errorf("Test Fail:\t word: %s", test)
+		} else {
+			t.Logf("Test Successful: word: %s", test)
+		}
+	}
+	dawg.ReverseStreeng()
+	nodes := dawg.NodeCount()
+	index := dawg.Add("zzyzx")
+	dawg.Remove(0)
+	if index != -1 || dawg.Contains("zzyzx") || len(dawg.EndWith("zyzx")) != 0 ||
+		!dawg.Contains(words[0]) || dawg.NodeCount() != nodes {
+		t.Errorf("Test Fail:\t dawg is not read-only")
+	}
+	var buffer bytes.Buffer
+	dawg.WriteTo(&buffer)
+	loaded, err := ReadStreeng(&buffer)
+	if err != nil || loaded.NodeCount() != dawg.NodeCount() ||
+		len(loaded.StartWith("th")) != len(dawg.StartWith("th")) {
+		t.Errorf("Test Fail:\t dawg is not read: %v", err)
+	}
+}
+
+func TestDAWGQueries(t *testing.T) {
+	words := strings.Fields("cat cart Cat cat dog dot café cafe do")
+	streeng := MakeStreeng(words)
+	dawg := MakeStreeng(words, DAWG())
+	sorted := func(v []int, err error) []int {
+		v = append([]int{}, v...)
+		sort.Ints(v)
+		return v
+	}
+	count := func(s *Streeng) int {
+		i := 0
+		s.Traverse(func(node *Node) { i += len(node.words) })
+		return i
+	}
+	streeng.BuildSuffixIndex()
+	dawg.BuildSuffixIndex()
+	if !reflect.DeepEqual(dawg.Terms(), streeng.Terms()) || count(dawg) != count(streeng) ||
+		!reflect.DeepEqual(sorted(dawg.Match("ca.*")), sorted(streeng.Match("ca.*"))) ||
+		!reflect.DeepEqual(sorted(dawg.Query("c?t")), sorted(streeng.Query("c?t"))) ||
+		!reflect.DeepEqual(dawg.SearchFuzzy("cot", 1), streeng.SearchFuzzy("cot", 1)) ||
+		!reflect.DeepEqual(sorted(dawg.ContainsSubstring("o"), nil), sorted(streeng.ContainsSubstring("o"), nil)) ||
+		!reflect.DeepEqual(dawg.Suggest("ca", 2), streeng.Suggest("ca", 2)) {
+		t.Errorf("Test Fail:\t dawg queries differ from trie")
+	} else {
+		t.Logf("Test Successful: dawg queries")
+	}
+	for _, fold := range []Fold{FoldCase, FoldAccents, FoldCase | FoldAccents} {
+		if !reflect.DeepEqual(dawg.Search("CAFE", fold), streeng.Search("CAFE", fold)) ||
+			!reflect.DeepEqual(dawg.StartWith("CA", fold), streeng.StartWith("CA", fold)) ||
+			dawg.Contains("Cafe", fold) != streeng.Contains("Cafe", fold) {
+			t.Errorf("Test Fail:\t fold: %d", fold)
+		} else {
+			t.Logf("Test Successful: fold: %d", fold)
+		}
+	}
+}
+
+func TestDAWGPlainTree(t *testing.T) {
+	dawg := MakeStreeng(strings.Fields("cat cart dog"), DAWG())
+	dawg.Match("ca.*")
+	plain := dawg.plain
+	dawg.SearchFuzzy("cot", 1)
+	if plain == nil || dawg.plain != plain {
+		t.Errorf("Test Fail:\t plain tree is not kept")
+	}
+	dawg.Clean()
+	if words, _ := dawg.Match("ca.*"); len(words) != 0 || dawg.plain == plain {
+		t.Errorf("Test Fail:\t plain tree after clean: %v", words)
+	} else {
+		t.Logf("Test Successful: plain tree")
+	}
+}
@@ -0,0 +1,151 @@
+package streeng
+
+import (
+	"sort"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold is a flag of query which matches equivalent runes of the tree
+type Fold int
+
+const (
+	// FoldCase matches runes which are equal under simple case folding
+	FoldCase Fold = 1 << iota
+	// FoldAccents matches runes which are equal without diacritics
+	FoldAccents
+)
+
+/*
+foldSearch walks every branch of tree which is equivalent to runes
+under flags and returns cursors where runes end with their paths.
+If reverse is true, runes are already reversed, so combining marks
+are passed before each rune. Otherwise they are passed after each
+rune, and after the last rune too if exact is true. Runes which
+are only combining marks match nothing under FoldAccents
+*/
+func foldSearch(root *Node, runic []rune, flags Fold, reverse, exact bool) ([]cursor, [][]rune) {
+	if flags&FoldAccents != 0 {
+		runic = stripRunes(runic)
+		if len(runic) == 0 {
+			return nil, nil
+		}
+	}
+	cursors := []cursor{{root, 1}}
+	paths := [][]rune{nil}
+	for k, v := range runic {
+		if reverse || k > 0 {
+			cursors, paths = passMarks(cursors, paths, flags)
+		}
+		key := foldKey(v, flags)
+		next, nextPaths := []cursor{}, [][]rune{}
+		for i, c := range cursors {
+			c.next(func(value rune, n cursor) {
+				if foldKey(value, flags) == key {
+					next = append(next, n)
+					nextPaths = append(nextPaths, append(paths[i][:len(paths[i]):len(paths[i])], value))
+				}
+			})
+		}
+		cursors, paths = next, nextPaths
+	}
+	if exact && !reverse {
+		cursors, paths = passMarks(cursors, paths, flags)
+	}
+	return cursors, paths
+}
+
+// passMarks adds cursors after combining marks which follow cursors
+func passMarks(cursors []cursor, paths [][]rune, flags Fold) ([]cursor, [][]rune) {
+	if flags&FoldAccents == 0 {
+		return cursors, paths
+	}
+	for i := 0; i < len(cursors); i++ {
+		cursors[i].next(func(value rune, n cursor) {
+			if unicode.Is(unicode.Mn, value) {
+				cursors = append(cursors, n)
+				paths = append(paths, append(paths[i][:len(paths[i]):len(paths[i])], value))
+			}
+		})
+	}
+	return cursors, paths
+}
+
+// foldSearchWords returns words of nodes which are at cursors
+func foldSearchWords(cursors []cursor) []int {
+	words := []int{}
+	for _, c := range cursors {
+		if c.end() {
+			words = append(words, c.node.words...)
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	sort.Ints(words)
+	return words
+}
+
+// foldSubstring returns words under cursors
+func foldSubstring(cursors []cursor) []int {
+	if len(cursors) == 0 {
+		return nil
+	}
+	words := []int{}
+	for _, c := range cursors {
+		getSubstring(&words, c.node)
+	}
+	sort.Ints(words)
+	return words
+}
+
+func foldFlags(fold []Fold) Fold {
+	flags := Fold(0)
+	for _, v := range fold {
+		flags |= v
+	}
+	return flags
+}
+
+// foldKey returns the same rune for runes which are equivalent under flags
+func foldKey(r rune, flags Fold) rune {
+	if flags&FoldAccents != 0 {
+		r = baseRune(r)
+	}
+	if flags&FoldCase != 0 {
+		key := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < key {
+				key = f
+			}
+		}
+		return key
+	}
+	return r
+}
+
+// baseRune returns rune without its diacritics
+func baseRune(r rune) rune {
+	if r < 0xC0 {
+		return r
+	}
+	decomposed := []rune(norm.NFD.String(string(r)))
+	for _, v := range decomposed[1:] {
+		if !unicode.Is(unicode.Mn, v) {
+			return r
+		}
+	}
+	return decomposed[0]
+}
+
+// stripRunes removes diacritics of runes
+func stripRunes(runic []rune) []rune {
+	stripped := []rune{}
+	for _, v := range []rune(norm.NFD.String(string(runic))) {
+		if !unicode.Is(unicode.Mn, v) {
+			stripped = append(stripped, v)
+		}
+	}
+	return stripped
+}
@@ -0,0 +1,92 @@
+package streeng
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func foldWord(word string, fold Fold) string {
+	if fold&FoldAccents != 0 {
+		runic := []rune{}
+		for _, v := range norm.NFD.String(word) {
+			if !unicode.Is(unicode.Mn, v) {
+				runic = append(runic, v)
+			}
+		}
+		word = string(runic)
+	}
+	if fold&FoldCase != 0 {
+		runic := []rune(word)
+		for k, v := range runic {
+			runic[k] = foldKey(v, FoldCase)
+		}
+		word = string(runic)
+	}
+	return word
+}
+
+func TestFold(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := append(strings.Fields(text),
+		"Café", "café", "CAFÉ", "café", "naïve", "NAIVE", "Élan")
+	tests := []string{`mrs.`, `ELIZABETH`, `cafe`, `CAFÉ`, `naive`, `élan`, `the`, `asd`}
+	folds := []Fold{FoldCase, FoldAccents, FoldCase | FoldAccents}
+	for _, radix := range []bool{false, true} {
+		opts := []Option{}
+		if radix {
+			opts = append(opts, Radix())
+		}
+		streeng := MakeStreeng(words, opts...)
+		streeng.ReverseStreeng()
+		for _, test := range tests {
+			for _, fold := range folds {
+				search, prefix, suffix := []int{}, []int{}, []int{}
+				key := foldWord(test, fold)
+				for k, word := range words {
+					word = foldWord(word, fold)
+					if word == key {
+						search = append(search, k)
+					}
+					if strings.HasPrefix(word, key) {
+						prefix = append(prefix, k)
+					}
+					if strings.HasSuffix(word, key) {
+						suffix = append(suffix, k)
+					}
+				}
+				results := streeng.Search(test, fold)
+				if results == nil {
+					results = []int{}
+				}
+				starts := streeng.StartWith(test, fold)
+				ends := streeng.EndWith(test, fold)
+				sort.Ints(starts)
+				sort.Ints(ends)
+				if reflect.DeepEqual(results, search) &&
+					streeng.Contains(test, fold) == (len(search) > 0) &&
+					len(starts) == len(prefix) && len(ends) == len(suffix) {
+					t.Logf("Test Successful: word: %s \t fold: %d", test, fold)
+				} else {
+					t.Errorf("Test Fail:\t word: %s \t fold: %d \t expected: %d %d %d \t result: %d %d %d",
+						test, fold, len(search), len(prefix), len(suffix),
+						len(results), len(starts), len(ends))
+				}
+			}
+		}
+		if streeng.Search("\u0301", FoldAccents) != nil || streeng.StartWith("\u0301", FoldAccents) != nil ||
+			streeng.EndWith("\u0301", FoldAccents) != nil {
+			t.Errorf("Test Fail:\t radix: %t \t only marks are matched", radix)
+		}
+	}
+	if MakeStreeng(words, DAWG()).StartWith("\u0301", FoldAccents) != nil {
+		t.Errorf("Test Fail:\t dawg \t only marks are matched")
+	}
+}
@@ -0,0 +1,333 @@
+package streeng
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+const (
+	frozenMagic   = "STRF"
+	frozenVersion = 1
+	frozenHeader  = 32
+	frozenNode    = 20
+)
+
+/*
+Frozen is a read-only streeng in a flat byte layout.
+Queries read the layout directly, so it can be memory-mapped
+from disk without making Go objects of nodes.
+
+Layout is little-endian uint32 values: a header, offsets of
+words, nodes of forward tree, nodes of reverse tree, postings
+and bytes of words. Each node has value, first child, count of
+children, first posting and count of postings. Children of a
+node are contiguous and sorted by value. Root is the first node.
+Queries hold a read lock and Close holds a write lock, so data
+is not released while a query reads it
+*/
+type Frozen struct {
+	mu       sync.RWMutex
+	data     []byte
+	count    int
+	forward  int
+	reverse  int
+	postings int
+	strings  int
+	lenOfFwd int
+	lenOfRev int
+	lenOfPst int
+	lenOfStr int
+	release  func() error
+}
+
+// frozenBuilder flattens trees into frozen layout
+type frozenBuilder struct {
+	nodes    []uint32
+	postings []uint32
+}
+
+/*
+WriteFrozen function writes streeng in frozen layout to w.
+Reverse tree is made for the layout if it was not built.
+Layout has a node for each rune, so radix trees and word
+graphs are made again as plain trees
+*/
+func (s *Streeng) WriteFrozen(w io.Writer) (int64, error) {
+	root, reverseRoot := s.root, s.reverseRoot
+	if s.radix || s.dawg {
+		root, _ = makeTree(s, false, false)
+		reverseRoot = nil
+	}
+	if reverseRoot == nil {
+		reverseRoot, _ = makeTree(s, true, false)
+	}
+	forward := new(frozenBuilder)
+	forward.flatten(root)
+	reverse := new(frozenBuilder)
+	reverse.postings = forward.postings
+	reverse.flatten(reverseRoot)
+	postings := reverse.postings
+	offsets := make([]uint32, len(s.words)+1)
+	lenOfStr := 0
+	for k, v := range s.words {
+		if !s.removed[k] {
+			lenOfStr += len(v)
+		}
+		offsets[k+1] = uint32(lenOfStr)
+	}
+	data := make([]byte, 0, frozenHeader+4*(len(offsets)+
+		len(forward.nodes)+len(reverse.nodes)+len(postings))+lenOfStr)
+	data = append(data, frozenMagic...)
+	for _, v := range []int{frozenVersion, len(s.words),
+		len(forward.nodes) / 5, len(reverse.nodes) / 5, len(postings),
+		lenOfStr, 0} {
+		data = binary.LittleEndian.AppendUint32(data, uint32(v))
+	}
+	for _, section := range [][]uint32{offsets, forward.nodes,
+		reverse.nodes, postings} {
+		for _, v := range section {
+			data = binary.LittleEndian.AppendUint32(data, v)
+		}
+	}
+	for k, v := range s.words {
+		if !s.removed[k] {
+			data = append(data, v...)
+		}
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+/*
+LoadFrozen function makes a frozen streeng over data.
+Data is not copied, so it must not change while frozen is used
+*/
+func LoadFrozen(data []byte) (*Frozen, error) {
+	if len(data) < frozenHeader {
+		return nil, &FormatError{len(data), "truncated data"}
+	}
+	if string(data[:len(frozenMagic)]) != frozenMagic {
+		return nil, &FormatError{0, "invalid magic"}
+	}
+	header := make([]uint64, 7)
+	for k := range header {
+		header[k] = uint64(binary.LittleEndian.Uint32(data[4+4*k:]))
+	}
+	if header[0] != frozenVersion {
+		return nil, &FormatError{4, fmt.Sprintf("unsupported version %d", header[0])}
+	}
+	f := new(Frozen)
+	f.data = data
+	f.count = int(header[1])
+	f.lenOfFwd = int(header[2])
+	f.lenOfRev = int(header[3])
+	f.lenOfPst = int(header[4])
+	f.lenOfStr = int(header[5])
+	size := uint64(frozenHeader) + 4*(header[1]+1) +
+		frozenNode*(header[2]+header[3]) + 4*header[4] + header[5]
+	if size != uint64(len(data)) || f.lenOfFwd == 0 || f.lenOfRev == 0 {
+		return nil, &FormatError{len(data), "invalid size"}
+	}
+	f.forward = frozenHeader + 4*(f.count+1)
+	f.reverse = f.forward + frozenNode*f.lenOfFwd
+	f.postings = f.reverse + frozenNode*f.lenOfRev
+	f.strings = f.postings + 4*f.lenOfPst
+	return f, nil
+}
+
+/*
+Close function releases data of frozen streeng. It waits for
+running queries, queries return nothing after it, and closing
+again does nothing
+*/
+func (f *Frozen) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	release := f.release
+	f.data, f.release = nil, nil
+	f.count = 0
+	if release != nil {
+		return release()
+	}
+	return nil
+}
+
+// Search function searches given word in the frozen tree
+func (f *Frozen) Search(word string) []int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	runic := []rune(word)
+	if len(runic) == 0 || f.data == nil {
+		return nil
+	}
+	node, ok := f.find(f.forward, f.lenOfFwd, runic)
+	if !ok {
+		return nil
+	}
+	words := []int{}
+	f.appendPostings(&words, f.forward, f.lenOfFwd, node)
+	if len(words) == 0 {
+		return nil
+	}
+	return words
+}
+
+// Contains returns whether or not the word exists in the frozen tree
+func (f *Frozen) Contains(word string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	runic := []rune(word)
+	if len(runic) == 0 || f.data == nil {
+		return false
+	}
+	node, ok := f.find(f.forward, f.lenOfFwd, runic)
+	return ok && f.field(f.forward, f.lenOfFwd, node, 4) > 0
+}
+
+// StartWith function searches words which start with given string
+func (f *Frozen) StartWith(word string) []int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	runic := []rune(word)
+	if len(runic) == 0 || f.data == nil {
+		return nil
+	}
+	node, ok := f.find(f.forward, f.lenOfFwd, runic)
+	if !ok {
+		return nil
+	}
+	words := []int{}
+	f.appendSubtree(&words, f.forward, f.lenOfFwd, node)
+	return words
+}
+
+// EndWith function searches words which end with given string
+func (f *Frozen) EndWith(word string) []int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	runic := reverseRunes([]rune(word))
+	if len(runic) == 0 || f.data == nil {
+		return nil
+	}
+	node, ok := f.find(f.reverse, f.lenOfRev, runic)
+	if !ok {
+		return nil
+	}
+	words := []int{}
+	f.appendSubtree(&words, f.reverse, f.lenOfRev, node)
+	return words
+}
+
+/*
+Words returns element of words,
+if index is out of range, it returns empty string
+*/
+func (f *Frozen) Words(index int) string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if index < 0 || index >= f.count || f.data == nil {
+		return ""
+	}
+	start := binary.LittleEndian.Uint32(f.data[frozenHeader+4*index:])
+	end := binary.LittleEndian.Uint32(f.data[frozenHeader+4*index+4:])
+	if start > end || int(end) > f.lenOfStr {
+		return ""
+	}
+	return string(f.data[f.strings+int(start) : f.strings+int(end)])
+}
+
+// Len returns count of words of frozen streeng
+func (f *Frozen) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.count
+}
+
+// field returns field of node in section, or 0 if node is out of range
+func (f *Frozen) field(section, lenOfSection, node, field int) int {
+	if node < 0 || node >= lenOfSection {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint32(f.data[section+frozenNode*node+4*field:]))
+}
+
+// child searches child of node with given value by binary search
+func (f *Frozen) child(section, lenOfSection, node int, value rune) (int, bool) {
+	first := f.field(section, lenOfSection, node, 1)
+	count := f.field(section, lenOfSection, node, 2)
+	if first+count > lenOfSection {
+		return 0, false
+	}
+	i := sort.Search(count, func(i int) bool {
+		return rune(f.field(section, lenOfSection, first+i, 0)) >= value
+	})
+	if i < count && rune(f.field(section, lenOfSection, first+i, 0)) == value {
+		return first + i, true
+	}
+	return 0, false
+}
+
+func (f *Frozen) find(section, lenOfSection int, runic []rune) (int, bool) {
+	node := 0
+	for _, v := range runic {
+		next, ok := f.child(section, lenOfSection, node, v)
+		if !ok {
+			return 0, false
+		}
+		node = next
+	}
+	return node, true
+}
+
+func (f *Frozen) appendPostings(words *[]int, section, lenOfSection, node int) {
+	first := f.field(section, lenOfSection, node, 3)
+	count := f.field(section, lenOfSection, node, 4)
+	if first+count > f.lenOfPst {
+		return
+	}
+	for i := first; i < first+count; i++ {
+		*words = append(*words,
+			int(binary.LittleEndian.Uint32(f.data[f.postings+4*i:])))
+	}
+}
+
+func (f *Frozen) appendSubtree(words *[]int, section, lenOfSection, node int) {
+	f.appendPostings(words, section, lenOfSection, node)
+	first := f.field(section, lenOfSection, node, 1)
+	count := f.field(section, lenOfSection, node, 2)
+	if first <= node || first+count > lenOfSection {
+		return
+	}
+	for i := first; i < first+count; i++ {
+		f.appendSubtree(words, section, lenOfSection, i)
+	}
+}
+
+/*
+flatten appends nodes of tree in breadth-first order,
+so children of each node are contiguous
+*/
+func (b *frozenBuilder) flatten(root *Node) {
+	queue := []*Node{root}
+	next := 1
+	for i := 0; i < len(queue); i++ {
+		node := queue[i]
+		keys := []rune{}
+		for k := range node.characters {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		b.nodes = append(b.nodes, uint32(node.value), uint32(next),
+			uint32(len(keys)), uint32(len(b.postings)), uint32(len(node.words)))
+		for _, v := range node.words {
+			b.postings = append(b.postings, uint32(v))
+		}
+		for _, v := range keys {
+			queue = append(queue, node.characters[v])
+		}
+		next += len(keys)
+	}
+}
@@ -0,0 +1,142 @@
+package streeng
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFrozen(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	streeng.Remove(0)
+	fileName := filepath.Join(t.TempDir(), "pp.strf")
+	file, err := os.Create(fileName)
+	if err != nil {
+		t.Fatalf("Test Fail:\t Create Error: %s", err.Error())
+	}
+	if _, err := streeng.WriteFrozen(file); err != nil {
+		t.Errorf("Test Fail:\t WriteFrozen Error: %s", err.Error())
+	}
+	file.Close()
+	frozen, err := OpenFrozen(fileName)
+	if err != nil {
+		t.Fatalf("Test Fail:\t OpenFrozen Error: %s", err.Error())
+	}
+	defer frozen.Close()
+	sorted := func(v []int) []int {
+		sort.Ints(v)
+		if v == nil {
+			v = []int{}
+		}
+		return v
+	}
+	tests := []string{`Mrs.`, `the`, `ness`, `disc`, `ion`, `asd`, ``, words[0], words[1]}
+	for _, test := range tests {
+		search, prefix, suffix := []int{}, []int{}, []int{}
+		for k, word := range words[1:] {
+			if word == test {
+				search = append(search, k+1)
+			}
+			if len(test) > 0 && strings.HasPrefix(word, test) {
+				prefix = append(prefix, k+1)
+			}
+			if len(test) > 0 && strings.HasSuffix(word, test) {
+				suffix = append(suffix, k+1)
+			}
+		}
+		if len(search) == 0 {
+			search = nil
+		}
+		if !reflect.DeepEqual(frozen.Search(test), search) ||
+			frozen.Contains(test) != (len(search) > 0) ||
+			!reflect.DeepEqual(sorted(frozen.StartWith(test)), prefix) ||
+			!reflect.DeepEqual(sorted(frozen.EndWith(test)), suffix) {
+			t.Errorf("Test Fail:\t word: %s", test)
+		} else {
+			t.Logf("Test Successful: word: %s", test)
+		}
+	}
+	if frozen.Len() != len(words) || frozen.Words(0) != "" || frozen.Words(1) != words[1] {
+		t.Errorf("Test Fail:\t words: %d %s", frozen.Len(), frozen.Words(1))
+	}
+	if err := frozen.Close(); err != nil {
+		t.Errorf("Test Fail:\t Close Error: %s", err.Error())
+	}
+	if frozen.Search(`the`) != nil || frozen.Contains(`the`) || frozen.StartWith(`the`) != nil ||
+		frozen.EndWith(`the`) != nil || frozen.Words(1) != "" || frozen.Len() != 0 {
+		t.Errorf("Test Fail:\t queries after close")
+	}
+	if err := frozen.Close(); err != nil {
+		t.Errorf("Test Fail:\t second Close Error: %s", err.Error())
+	}
+}
+
+func TestLoadFrozenCorrupt(t *testing.T) {
+	streeng := MakeStreeng(strings.Fields("This is a text to test"))
+	var buffer bytes.Buffer
+	streeng.WriteFrozen(&buffer)
+	data := buffer.Bytes()
+	for i := 0; i < len(data); i++ {
+		corrupt := append([]byte{}, data...)
+		corrupt[i] ^= 0xff
+		frozen, err := LoadFrozen(corrupt)
+		if err == nil {
+			for _, test := range []string{`This`, `te`, `t`, `is`} {
+				frozen.Search(test)
+				frozen.StartWith(test)
+				frozen.EndWith(test)
+			}
+			frozen.Words(1)
+		}
+	}
+	if _, err := LoadFrozen(data[:len(data)-1]); err == nil {
+		t.Errorf("Test Fail:\t truncated data is loaded")
+	}
+}
+
+func TestFrozenCloseConcurrent(t *testing.T) {
+	streeng := MakeStreeng(strings.Fields("This is a text to test"))
+	fileName := filepath.Join(t.TempDir(), "text.strf")
+	file, err := os.Create(fileName)
+	if err != nil {
+		t.Fatalf("Test Fail:\t Create Error: %s", err.Error())
+	}
+	streeng.WriteFrozen(file)
+	file.Close()
+	frozen, err := OpenFrozen(fileName)
+	if err != nil {
+		t.Fatalf("Test Fail:\t OpenFrozen Error: %s", err.Error())
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				frozen.Search(`text`)
+				frozen.StartWith(`te`)
+				frozen.EndWith(`t`)
+				frozen.Words(3)
+			}
+		}()
+	}
+	if err := frozen.Close(); err != nil {
+		t.Errorf("Test Fail:\t Close Error: %s", err.Error())
+	}
+	wg.Wait()
+	if frozen.Contains(`text`) || frozen.Len() != 0 {
+		t.Errorf("Test Fail:\t queries after concurrent close")
+	} else {
+		t.Logf("Test Successful: concurrent close")
+	}
+}
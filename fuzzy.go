@@ -0,0 +1,98 @@
+package streeng
+
+import "sort"
+
+// FuzzyResult is a struct of a term found by fuzzy search
+type FuzzyResult struct {
+	Term     string
+	Distance int
+	Words    []int
+}
+
+/*
+SearchFuzzy function searches terms which are at most maxDist
+Levenshtein distance far from given word. Results are grouped
+by term and sorted by distance
+*/
+func (s *Streeng) SearchFuzzy(word string, maxDist int) []FuzzyResult {
+	if s == nil || s.root == nil || maxDist < 0 {
+		return nil
+	}
+	runic := []rune(word)
+	row := make([]int, len(runic)+1)
+	for i := range row {
+		row[i] = i
+	}
+	results := []FuzzyResult{}
+	path := []rune{}
+	for _, v := range plainRoot(s).characters {
+		fuzzyChild(v, runic, row, path, maxDist, &results)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].Term < results[j].Term
+	})
+	return results
+}
+
+/*
+fuzzyChild calculates next rows of distance table for runes of
+node and goes to children while minimum of the row is not
+bigger than maxDist
+*/
+func fuzzyChild(node *Node, runic []rune, prev []int, path []rune,
+	maxDist int, results *[]FuzzyResult) {
+	row, min := fuzzyRow(runic, prev, node.value)
+	path = append(path, node.value)
+	for _, v := range node.label {
+		if min > maxDist {
+			return
+		}
+		row, min = fuzzyRow(runic, row, v)
+		path = append(path, v)
+	}
+	lenOfRow := len(row)
+	if len(node.words) > 0 && row[lenOfRow-1] <= maxDist {
+		words := []int{}
+		for _, v := range node.words {
+			words = append(words, v)
+		}
+		*results = append(*results, FuzzyResult{
+			Term:     string(path),
+			Distance: row[lenOfRow-1],
+			Words:    words,
+		})
+	}
+	if min <= maxDist {
+		for _, v := range node.characters {
+			fuzzyChild(v, runic, row, path, maxDist, results)
+		}
+	}
+}
+
+// fuzzyRow calculates row of given rune and returns it with its minimum
+func fuzzyRow(runic []rune, prev []int, value rune) ([]int, int) {
+	lenOfRow := len(prev)
+	row := make([]int, lenOfRow)
+	row[0] = prev[0] + 1
+	min := row[0]
+	for i := 1; i < lenOfRow; i++ {
+		cost := 1
+		if runic[i-1] == value {
+			cost = 0
+		}
+		row[i] = prev[i-1] + cost
+		if prev[i]+1 < row[i] {
+			row[i] = prev[i] + 1
+		}
+		if row[i-1]+1 < row[i] {
+			row[i] = row[i-1] + 1
+		}
+		if row[i] < min {
+			min = row[i]
+		}
+	}
+	return row, min
+}
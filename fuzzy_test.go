@@ -0,0 +1,76 @@
+package streeng
+
+import (
+	"strings"
+	"testing"
+)
+
+func levenshtein(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	prev := make([]int, len(y)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		row := make([]int, len(y)+1)
+		row[0] = i
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			row[j] = prev[j-1] + cost
+			if prev[j]+1 < row[j] {
+				row[j] = prev[j] + 1
+			}
+			if row[j-1]+1 < row[j] {
+				row[j] = row[j-1] + 1
+			}
+		}
+		prev = row
+	}
+	return prev[len(y)]
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	fileName := "pp.txt"
+	tests := []string{
+		`discretoin`,
+		`exclamaton`,
+		`wnat`,
+		`fortune`,
+		`Mrs`,
+		`neighbor`,
+		``,
+	}
+	text, err := StringFromFile(fileName)
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	for _, test := range tests {
+		for maxDist := 0; maxDist <= 2; maxDist++ {
+			i := 0
+			for _, word := range streeng.words {
+				if levenshtein(test, word) <= maxDist {
+					i++
+				}
+			}
+			j := 0
+			for _, result := range streeng.SearchFuzzy(test, maxDist) {
+				if levenshtein(test, result.Term) != result.Distance {
+					t.Errorf("Test Fail:\t word: %s \t term: %s \t distance: %d",
+						test, result.Term, result.Distance)
+				}
+				j += len(result.Words)
+			}
+			if i == j {
+				t.Logf("Test Successful: word: %s \t distance: %d", test, maxDist)
+			} else {
+				t.Errorf("Test Fail:\t word: %s \t distance: %d \t expected: %d \t result: %d",
+					test, maxDist, i, j)
+			}
+		}
+	}
+}
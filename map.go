@@ -0,0 +1,145 @@
+package streeng
+
+/*
+Map is a rune tree which keeps a value for each key. It is made
+of the same nodes as Streeng, words of a node is the slot of
+its value, so a Streeng is like a Map whose values are word indexes
+*/
+type Map[V any] struct {
+	root        *Node
+	reverseRoot *Node
+	keys        []string
+	values      []V
+	free        []int
+	length      int
+}
+
+// MakeMap makes an empty map
+func MakeMap[V any]() *Map[V] {
+	m := new(Map[V])
+	m.root = new(Node)
+	m.root.characters = make(map[rune]*Node)
+	m.reverseRoot = new(Node)
+	m.reverseRoot.characters = make(map[rune]*Node)
+	return m
+}
+
+// Put function sets value of key
+func (m *Map[V]) Put(key string, value V) {
+	if slot, ok := m.slot(key); ok {
+		m.values[slot] = value
+		return
+	}
+	slot := len(m.keys)
+	if len(m.free) > 0 {
+		slot = m.free[len(m.free)-1]
+		m.free = m.free[:len(m.free)-1]
+		m.keys[slot], m.values[slot] = key, value
+	} else {
+		m.keys = append(m.keys, key)
+		m.values = append(m.values, value)
+	}
+	runic := []rune(key)
+	if len(runic) == 0 {
+		m.root.words = []int{slot}
+		m.reverseRoot.words = []int{slot}
+	} else {
+		addRunes(m.root, runic, slot)
+		addRunes(m.reverseRoot, reverseRunes(runic), slot)
+	}
+	m.length++
+}
+
+// Get function returns value of key and whether or not key exists
+func (m *Map[V]) Get(key string) (V, bool) {
+	if slot, ok := m.slot(key); ok {
+		return m.values[slot], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Delete function removes key and returns whether or not it existed
+func (m *Map[V]) Delete(key string) bool {
+	slot, ok := m.slot(key)
+	if !ok {
+		return false
+	}
+	runic := []rune(key)
+	if len(runic) == 0 {
+		m.root.words = nil
+		m.reverseRoot.words = nil
+	} else {
+		removeRunes(m.root, runic, slot)
+		removeRunes(m.reverseRoot, reverseRunes(runic), slot)
+	}
+	var zero V
+	m.keys[slot], m.values[slot] = "", zero
+	m.free = append(m.free, slot)
+	m.length--
+	return true
+}
+
+// Len function returns count of keys
+func (m *Map[V]) Len() int {
+	return m.length
+}
+
+/*
+PrefixScan function calls fn for keys which start with prefix
+in order. Scan stops when fn returns false
+*/
+func (m *Map[V]) PrefixScan(prefix string, fn func(key string, value V) bool) {
+	if tempNode, _ := descend(m.root, []rune(prefix)); tempNode != nil {
+		walkWords(tempNode, func(slot int) bool {
+			return fn(m.keys[slot], m.values[slot])
+		})
+	}
+}
+
+/*
+SuffixScan function calls fn for keys which end with suffix
+in order of their reversed runes. Scan stops when fn returns false
+*/
+func (m *Map[V]) SuffixScan(suffix string, fn func(key string, value V) bool) {
+	if tempNode, _ := descend(m.reverseRoot, reverseRunes([]rune(suffix))); tempNode != nil {
+		walkWords(tempNode, func(slot int) bool {
+			return fn(m.keys[slot], m.values[slot])
+		})
+	}
+}
+
+/*
+LongestPrefix function returns the longest key which is
+a prefix of input with its value
+*/
+func (m *Map[V]) LongestPrefix(input string) (string, V, bool) {
+	slot := -1
+	if len(m.root.words) > 0 {
+		slot = m.root.words[0]
+	}
+	tempNode := m.root
+	for _, r := range input {
+		tempNode = tempNode.characters[r]
+		if tempNode == nil {
+			break
+		}
+		if len(tempNode.words) > 0 {
+			slot = tempNode.words[0]
+		}
+	}
+	if slot < 0 {
+		var zero V
+		return "", zero, false
+	}
+	return m.keys[slot], m.values[slot], true
+}
+
+// slot returns slot of key's value
+func (m *Map[V]) slot(key string) (int, bool) {
+	tempNode, exact := descend(m.root, []rune(key))
+	if !exact || len(tempNode.words) == 0 {
+		return 0, false
+	}
+	return tempNode.words[0], true
+}
@@ -0,0 +1,100 @@
+package streeng
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	m := MakeMap[[]int]()
+	expected := make(map[string][]int)
+	for k, word := range words[:20000] {
+		v, _ := m.Get(word)
+		m.Put(word, append(v, k))
+		expected[word] = append(expected[word], k)
+	}
+	for _, word := range words[20000:25000] {
+		if m.Delete(word) != (expected[word] != nil) {
+			t.Errorf("Test Fail:\t delete: %s", word)
+		}
+		delete(expected, word)
+	}
+	m.Put("", []int{-1})
+	expected[""] = []int{-1}
+	m.Put("the", []int{-2})
+	expected["the"] = []int{-2}
+	if m.Len() != len(expected) {
+		t.Errorf("Test Fail:\t len: %d \t expected: %d", m.Len(), len(expected))
+	}
+	for _, word := range words[:30000] {
+		v, ok := m.Get(word)
+		if ok != (expected[word] != nil) || !reflect.DeepEqual(v, expected[word]) {
+			t.Errorf("Test Fail:\t get: %s", word)
+			break
+		}
+	}
+	tests := []string{`th`, `Mr`, `ness`, `ion`, `asd`, `é`}
+	for _, test := range tests {
+		prefix, suffix := []string{}, []string{}
+		for k := range expected {
+			if strings.HasPrefix(k, test) {
+				prefix = append(prefix, k)
+			}
+			if strings.HasSuffix(k, test) {
+				suffix = append(suffix, k)
+			}
+		}
+		sort.Strings(prefix)
+		sort.Strings(suffix)
+		prefixScan, suffixScan := []string{}, []string{}
+		m.PrefixScan(test, func(key string, value []int) bool {
+			if !reflect.DeepEqual(value, expected[key]) {
+				t.Errorf("Test Fail:\t prefix scan value: %s", key)
+			}
+			prefixScan = append(prefixScan, key)
+			return true
+		})
+		m.SuffixScan(test, func(key string, value []int) bool {
+			suffixScan = append(suffixScan, key)
+			return true
+		})
+		sort.Strings(suffixScan)
+		if reflect.DeepEqual(prefixScan, prefix) && reflect.DeepEqual(suffixScan, suffix) {
+			t.Logf("Test Successful: scan: %s", test)
+		} else {
+			t.Errorf("Test Fail:\t scan: %s \t expected: %d %d \t result: %d %d",
+				test, len(prefix), len(suffix), len(prefixScan), len(suffixScan))
+		}
+	}
+	count := 0
+	m.PrefixScan("", func(key string, value []int) bool {
+		count++
+		return count < 5
+	})
+	if count != 5 {
+		t.Errorf("Test Fail:\t scan stop: %d", count)
+	}
+	for _, test := range []string{`Darcyness`, `the`, `thereby`, `qqq`, ``} {
+		key := ""
+		for k := range expected {
+			if strings.HasPrefix(test, k) && len(k) >= len(key) {
+				key = k
+			}
+		}
+		result, value, ok := m.LongestPrefix(test)
+		if !ok || result != key || !reflect.DeepEqual(value, expected[key]) {
+			t.Errorf("Test Fail:\t longest prefix: %s \t expected: %s \t result: %s", test, key, result)
+		}
+	}
+	m.Delete("")
+	if _, _, ok := m.LongestPrefix("qqq"); ok {
+		t.Errorf("Test Fail:\t longest prefix of deleted empty key")
+	}
+}
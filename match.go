@@ -0,0 +1,218 @@
+package streeng
+
+import (
+	"regexp/syntax"
+)
+
+/*
+matcher runs compiled program of a regular expression
+alongside the tree, so subtrees which can not match are skipped.
+Walk stops when done is closed
+*/
+type matcher struct {
+	prog     *syntax.Prog
+	anchored bool
+	seen     []uint32
+	gen      uint32
+	done     <-chan struct{}
+}
+
+func makeMatcher(regex string) (*matcher, error) {
+	re, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	prog, err2 := syntax.Compile(re.Simplify())
+	if err2 != nil {
+		return nil, err2
+	}
+	m := new(matcher)
+	m.prog = prog
+	m.anchored = prog.StartCond()&syntax.EmptyBeginText != 0
+	m.seen = make([]uint32, len(prog.Inst))
+	return m, nil
+}
+
+/*
+walk takes threads which wait before closure after node and calls
+emit for matching words of node and its children. prev is the
+last rune of node, or -1 on root. It returns false when emit
+returns false, so the walk stops
+*/
+func (m *matcher) walk(node *Node, prev rune, pending []uint32, emit func(int) bool) bool {
+	if m.done != nil {
+		select {
+		case <-m.done:
+			return false
+		default:
+		}
+	}
+	if !m.anchored || prev == -1 {
+		pending = append(pending, uint32(m.prog.Start))
+	}
+	if len(pending) == 0 {
+		return true
+	}
+	if len(node.words) > 0 {
+		if _, matched := m.closure(pending, syntax.EmptyOpContext(prev, -1)); matched {
+			for _, v := range node.words {
+				if !emit(v) {
+					return false
+				}
+			}
+		}
+	}
+	for _, v := range node.characters {
+		if !m.walkEdge(v, prev, pending, emit) {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+walkEdge moves pending threads along runes of node's edge.
+If match instruction is reached before a rune, every word
+under node matches
+*/
+func (m *matcher) walkEdge(node *Node, prev rune, pending []uint32, emit func(int) bool) bool {
+	value := node.value
+	for i := 0; ; i++ {
+		threads, matched := m.closure(pending, syntax.EmptyOpContext(prev, value))
+		if matched {
+			return walkWords(node, emit)
+		}
+		pending = m.step(threads, value)
+		prev = value
+		if i == len(node.label) {
+			break
+		}
+		value = node.label[i]
+		if !m.anchored {
+			pending = append(pending, uint32(m.prog.Start))
+		}
+		if len(pending) == 0 {
+			return true
+		}
+	}
+	return m.walk(node, prev, pending, emit)
+}
+
+/*
+walkTask moves threads along chain of task like walk and walkEdge,
+but only the node of task is visited, or its subtree if task is deep
+*/
+func (m *matcher) walkTask(task walkTask, emit func(int) bool) bool {
+	node := task.chain[len(task.chain)-1]
+	prev := rune(-1)
+	pending := []uint32{uint32(m.prog.Start)}
+	for k, v := range task.chain {
+		if k > 0 && !m.anchored {
+			pending = append(pending, uint32(m.prog.Start))
+		}
+		if len(pending) == 0 {
+			return true
+		}
+		value := v.value
+		for i := 0; ; i++ {
+			threads, matched := m.closure(pending, syntax.EmptyOpContext(prev, value))
+			if matched {
+				if task.deep {
+					return walkWords(node, emit)
+				}
+				for _, w := range node.words {
+					if !emit(w) {
+						return false
+					}
+				}
+				return true
+			}
+			pending = m.step(threads, value)
+			prev = value
+			if i == len(v.label) {
+				break
+			}
+			value = v.label[i]
+			if !m.anchored {
+				pending = append(pending, uint32(m.prog.Start))
+			}
+			if len(pending) == 0 {
+				return true
+			}
+		}
+	}
+	if task.deep {
+		return m.walk(node, prev, pending, emit)
+	}
+	if !m.anchored {
+		pending = append(pending, uint32(m.prog.Start))
+	}
+	if len(node.words) > 0 && len(pending) > 0 {
+		if _, matched := m.closure(pending, syntax.EmptyOpContext(prev, -1)); matched {
+			for _, w := range node.words {
+				if !emit(w) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+/*
+closure follows empty transitions from pending threads with
+given context. It returns threads waiting for a rune and
+whether match instruction was reached
+*/
+func (m *matcher) closure(pending []uint32, ctx syntax.EmptyOp) ([]uint32, bool) {
+	m.gen++
+	threads := []uint32{}
+	matched := false
+	stack := append([]uint32{}, pending...)
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if m.seen[pc] == m.gen {
+			continue
+		}
+		m.seen[pc] = m.gen
+		inst := &m.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Arg, inst.Out)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			if syntax.EmptyOp(inst.Arg)&^ctx == 0 {
+				stack = append(stack, inst.Out)
+			}
+		case syntax.InstMatch:
+			matched = true
+		case syntax.InstRune, syntax.InstRune1,
+			syntax.InstRuneAny, syntax.InstRuneAnyNotNL:
+			threads = append(threads, pc)
+		}
+	}
+	return threads, matched
+}
+
+// step moves threads which accept given rune
+func (m *matcher) step(threads []uint32, r rune) []uint32 {
+	next := []uint32{}
+	for _, pc := range threads {
+		inst := &m.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstRuneAny:
+			next = append(next, inst.Out)
+		case syntax.InstRuneAnyNotNL:
+			if r != '\n' {
+				next = append(next, inst.Out)
+			}
+		default:
+			if inst.MatchRune(r) {
+				next = append(next, inst.Out)
+			}
+		}
+	}
+	return next
+}
@@ -0,0 +1,104 @@
+package streeng
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var matchTests = []string{
+	`http(s)?://.*`,
+	`^http`,
+	`c..t`,
+	`.*ion$`,
+	`\bthe\b`,
+	`(?i)^elizabeth`,
+	`^[A-Z][a-z]+,$`,
+	`(?m)^Mr`,
+	`ness\.?$`,
+	`\B`,
+	`^$`,
+	`x*`,
+}
+
+func TestMatchPruned(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	for _, test := range matchTests {
+		re := regexp.MustCompile(test)
+		expected := []int{}
+		for k, word := range streeng.words {
+			if re.MatchString(word) {
+				expected = append(expected, k)
+			}
+		}
+		results, err2 := streeng.Match(test)
+		if err2 != nil {
+			t.Errorf("Test Fail:\t regex: %s\t error in Match", test)
+		}
+		sort.Ints(results)
+		if reflect.DeepEqual(results, expected) {
+			t.Logf("Test Successful: regex: %s", test)
+		} else {
+			t.Errorf("Test Fail:\t regex: %s \t expected: %d \t result: %d",
+				test, len(expected), len(results))
+		}
+	}
+}
+
+// scanMatch is the full tree scan which Match used before pruning
+func scanMatch(s *Streeng, regex string) []int {
+	re := regexp.MustCompile(regex)
+	var mutex sync.Mutex
+	results := []int{}
+	s.GoTraverse(func(node *Node) {
+		if re.MatchString(s.words[node.words[0]]) {
+			mutex.Lock()
+			results = append(results, node.words...)
+			mutex.Unlock()
+		}
+	})
+	return results
+}
+
+func benchmarkMatch(b *testing.B, regex string, scan bool) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		b.Fatalf("StringFromFile Error: %s\n", err.Error())
+	}
+	streeng := MakeStreeng(strings.Fields(text))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if scan {
+			scanMatch(streeng, regex)
+		} else {
+			streeng.Match(regex)
+		}
+	}
+}
+
+func BenchmarkMatchPrefix(b *testing.B)     { benchmarkMatch(b, `^http`, false) }
+func BenchmarkMatchPrefixScan(b *testing.B) { benchmarkMatch(b, `^http`, true) }
+func BenchmarkMatchSuffix(b *testing.B)     { benchmarkMatch(b, `ion$`, false) }
+func BenchmarkMatchSuffixScan(b *testing.B) { benchmarkMatch(b, `ion$`, true) }
+func BenchmarkMatchInfix(b *testing.B)      { benchmarkMatch(b, `c..t`, false) }
+func BenchmarkMatchInfixScan(b *testing.B)  { benchmarkMatch(b, `c..t`, true) }
+
+func BenchmarkStartWith(b *testing.B) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		b.Fatalf("StringFromFile Error: %s\n", err.Error())
+	}
+	streeng := MakeStreeng(strings.Fields(text))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		streeng.StartWith("http")
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package streeng
+
+import (
+	"io/ioutil"
+	"path/filepath"
+)
+
+/*
+OpenFrozen function reads frozen streeng file.
+Memory mapping is not supported on this platform,
+so file is read to memory
+*/
+func OpenFrozen(fileName string) (*Frozen, error) {
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return nil, err
+	}
+	data, err2 := ioutil.ReadFile(abs)
+	if err2 != nil {
+		return nil, err2
+	}
+	return LoadFrozen(data)
+}
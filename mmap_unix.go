@@ -0,0 +1,41 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package streeng
+
+import (
+	"os"
+	"syscall"
+)
+
+/*
+OpenFrozen function maps frozen streeng file to memory.
+Pages are shared between processes which map the same file
+*/
+func OpenFrozen(fileName string) (*Frozen, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err2 := file.Stat()
+	if err2 != nil {
+		return nil, err2
+	}
+	if info.Size() < frozenHeader {
+		return nil, &FormatError{int(info.Size()), "truncated data"}
+	}
+	data, err3 := syscall.Mmap(int(file.Fd()), 0, int(info.Size()),
+		syscall.PROT_READ, syscall.MAP_SHARED)
+	if err3 != nil {
+		return nil, err3
+	}
+	f, err4 := LoadFrozen(data)
+	if err4 != nil {
+		syscall.Munmap(data)
+		return nil, err4
+	}
+	f.release = func() error {
+		return syscall.Munmap(data)
+	}
+	return f, nil
+}
@@ -0,0 +1,54 @@
+package streeng
+
+import "golang.org/x/text/unicode/norm"
+
+/*
+Option is a function which sets an option of MakeStreeng
+and MakeStreengFromText
+*/
+type Option func(*options)
+
+type options struct {
+	radix     bool
+	dawg      bool
+	tokenizer Tokenizer
+	form      norm.Form
+	normalize bool
+	fold      bool
+	strip     bool
+	trim      bool
+}
+
+/*
+Radix option builds path-compressed radix trees. A chain of
+nodes which have one child and no words is kept in one node
+*/
+func Radix() Option {
+	return func(o *options) {
+		o.radix = true
+	}
+}
+
+/*
+DAWG option builds forward tree as a minimal deterministic
+acyclic word graph, so suffixes of words are shared like
+prefixes. Search, Contains, StartWith and Suggest use the graph
+and postings of terms. Traverse, Walk, Match and SearchFuzzy need
+nodes which keep words, so a plain tree is made on first use and
+kept until the tree is cleaned. EndWith uses reverse tree as
+usual. The graph is read-only like a snapshot, so Add returns -1
+and Remove does nothing. If Radix option is given too, it is ignored
+*/
+func DAWG() Option {
+	return func(o *options) {
+		o.dawg = true
+	}
+}
+
+func makeOptions(opts []Option) *options {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
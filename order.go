@@ -0,0 +1,179 @@
+package streeng
+
+import "sort"
+
+// sortedChildren returns children of node in order of their runes
+func sortedChildren(node *Node) []*Node {
+	children := make([]*Node, 0, len(node.characters))
+	for _, k := range sortedRunes(node) {
+		children = append(children, node.characters[k])
+	}
+	return children
+}
+
+/*
+sortedRunes returns runes of node's children in order. Nodes
+of a word graph are shared, so runes are keys of characters
+*/
+func sortedRunes(node *Node) []rune {
+	runes := make([]rune, 0, len(node.characters))
+	for k := range node.characters {
+		runes = append(runes, k)
+	}
+	sort.Slice(runes, func(i, j int) bool {
+		return runes[i] < runes[j]
+	})
+	return runes
+}
+
+/*
+Rank function returns count of distinct terms which are smaller
+than given word. Terms are ordered by their runes
+*/
+func (s *Streeng) Rank(word string) int {
+	if s == nil || s.root == nil {
+		return 0
+	}
+	runic := []rune(word)
+	lenOfWord := len(runic)
+	rank := 0
+	tempNode := s.root
+	for i := 0; i < lenOfWord; {
+		if tempNode != s.root && isTerm(s, tempNode) {
+			rank++
+		}
+		for k, v := range tempNode.characters {
+			if k < runic[i] {
+				rank += v.size
+			}
+		}
+		child := tempNode.characters[runic[i]]
+		if child == nil {
+			return rank
+		}
+		i++
+		for _, v := range child.label {
+			if i == lenOfWord || v > runic[i] {
+				return rank
+			}
+			if v < runic[i] {
+				return rank + child.size
+			}
+			i++
+		}
+		tempNode = child
+	}
+	return rank
+}
+
+/*
+Select function returns the term of given rank, so it is
+the inverse of Rank. It returns empty string if there is
+not a term of rank
+*/
+func (s *Streeng) Select(rank int) string {
+	terms := s.selectTerms(rank, 1)
+	if len(terms) == 0 {
+		return ""
+	}
+	return terms[0]
+}
+
+/*
+Range function returns distinct terms which are not smaller
+than from and smaller than to, in order
+*/
+func (s *Streeng) Range(from, to string) []string {
+	lo, hi := s.Rank(from), s.Rank(to)
+	if hi <= lo {
+		return []string{}
+	}
+	return s.selectTerms(lo, hi-lo)
+}
+
+// Successor function returns the smallest term which is bigger than word
+func (s *Streeng) Successor(word string) (string, bool) {
+	rank := s.Rank(word)
+	if s.Contains(word) {
+		rank++
+	}
+	terms := s.selectTerms(rank, 1)
+	if len(terms) == 0 {
+		return "", false
+	}
+	return terms[0], true
+}
+
+// Predecessor function returns the biggest term which is smaller than word
+func (s *Streeng) Predecessor(word string) (string, bool) {
+	rank := s.Rank(word)
+	if rank == 0 {
+		return "", false
+	}
+	terms := s.selectTerms(rank-1, 1)
+	if len(terms) == 0 {
+		return "", false
+	}
+	return terms[0], true
+}
+
+// selectTerms returns count terms in order from term of rank
+func (s *Streeng) selectTerms(rank, count int) []string {
+	terms := []string{}
+	if s != nil && s.root != nil && rank >= 0 && count > 0 {
+		selectChild(s, s.root, nil, &rank, count, &terms)
+	}
+	return terms
+}
+
+/*
+selectChild appends terms under node to terms in order. Subtrees
+which have less terms than skip are passed by their sizes
+*/
+func selectChild(s *Streeng, node *Node, path []rune, skip *int, count int, terms *[]string) {
+	if node != s.root && isTerm(s, node) {
+		if *skip == 0 {
+			*terms = append(*terms, string(path))
+		} else {
+			*skip--
+		}
+	}
+	for _, k := range sortedRunes(node) {
+		if len(*terms) == count {
+			return
+		}
+		v := node.characters[k]
+		if *skip >= v.size {
+			*skip -= v.size
+			continue
+		}
+		next := append(append(path[:len(path):len(path)], k), v.label...)
+		selectChild(s, v, next, skip, count, terms)
+	}
+}
+
+/*
+walkWords calls fn for words of node and its children in order.
+It returns false when fn returns false, so the walk stops
+*/
+func walkWords(node *Node, fn func(int) bool) bool {
+	for _, v := range node.words {
+		if !fn(v) {
+			return false
+		}
+	}
+	for _, v := range sortedChildren(node) {
+		if !walkWords(v, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTerm reports whether a term ends at node
+func isTerm(s *Streeng, node *Node) bool {
+	if s.dawg {
+		return node.final
+	}
+	return len(node.words) > 0
+}
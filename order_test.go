@@ -0,0 +1,115 @@
+package streeng
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	for _, opt := range []string{"trie", "radix", "dawg", "read"} {
+		opts := []Option{}
+		switch opt {
+		case "radix":
+			opts = append(opts, Radix())
+		case "dawg":
+			opts = append(opts, DAWG())
+		}
+		streeng := MakeStreeng(words[:len(words)-20], opts...)
+		for _, word := range words[len(words)-20:] {
+			streeng.Add(word)
+		}
+		for i := 0; i < 20; i++ {
+			streeng.Remove(i)
+		}
+		if opt == "dawg" {
+			streeng = MakeStreeng(words[20:], opts...)
+		}
+		if opt == "read" {
+			var buffer bytes.Buffer
+			streeng.WriteTo(&buffer)
+			streeng, err = ReadStreeng(&buffer)
+			if err != nil {
+				t.Fatalf("Test Fail:\t ReadStreeng Error: %s", err.Error())
+			}
+		}
+		seen := make(map[string]bool)
+		terms := []string{}
+		for _, word := range words[20:] {
+			if !seen[word] {
+				seen[word] = true
+				terms = append(terms, word)
+			}
+		}
+		sort.Strings(terms)
+		for k, v := range terms {
+			if streeng.Rank(v) != k || streeng.Select(k) != v {
+				t.Errorf("Test Fail:\t %s \t term: %s \t rank: %d \t select: %s",
+					opt, v, streeng.Rank(v), streeng.Select(k))
+				break
+			}
+		}
+		if streeng.Select(len(terms)) != "" || streeng.Select(-1) != "" {
+			t.Errorf("Test Fail:\t %s \t select out of range", opt)
+		}
+		tests := [][2]string{{`a`, `b`}, {`Mr`, `Ms`}, {`disc`, `dis~`}, {``, `A`},
+			{`zz`, `zzz`}, {`the`, `the`}, {`pride`, `prid`}, {`é`, `ü`}}
+		for _, test := range tests {
+			lo := sort.SearchStrings(terms, test[0])
+			hi := sort.SearchStrings(terms, test[1])
+			expected := []string{}
+			if lo < hi {
+				expected = terms[lo:hi]
+			}
+			results := streeng.Range(test[0], test[1])
+			succ, ok := streeng.Successor(test[0])
+			j := lo
+			if j < len(terms) && terms[j] == test[0] {
+				j++
+			}
+			okSucc := ok == (j < len(terms)) && (!ok || succ == terms[j])
+			pred, ok := streeng.Predecessor(test[0])
+			okPred := ok == (lo > 0) && (!ok || pred == terms[lo-1])
+			if reflect.DeepEqual(results, expected) && okSucc && okPred {
+				t.Logf("Test Successful: %s \t range: %s %s", opt, test[0], test[1])
+			} else {
+				t.Errorf("Test Fail:\t %s \t range: %s %s \t expected: %d \t result: %d \t %s %s",
+					opt, test[0], test[1], len(expected), len(results), succ, pred)
+			}
+		}
+		if opt == "dawg" {
+			continue
+		}
+		first := streeng.StartWith("th")
+		for i := 0; i < 5; i++ {
+			if !reflect.DeepEqual(streeng.StartWith("th"), first) {
+				t.Errorf("Test Fail:\t %s \t start with is not deterministic", opt)
+				break
+			}
+		}
+		previous := ""
+		for _, v := range first {
+			if streeng.Words(v) < previous {
+				t.Errorf("Test Fail:\t %s \t start with is not sorted: %s %s",
+					opt, previous, streeng.Words(v))
+				break
+			}
+			previous = streeng.Words(v)
+		}
+		streeng.Terms()
+		tokens := streeng.TokenList()
+		for k, v := range terms {
+			if tokens[streeng.Search(v)[0]] != k+1 {
+				t.Errorf("Test Fail:\t %s \t token of %s: %d", opt, v, tokens[streeng.Search(v)[0]])
+				break
+			}
+		}
+	}
+}
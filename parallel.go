@@ -0,0 +1,213 @@
+package streeng
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// matchTerms is count of terms under which trees are matched by one worker
+const matchTerms = 1 << 12
+
+/*
+walkTask is a part of the tree for a worker. chain is the path
+of nodes from a child of root to the node of task. If deep is
+false, only the node is visited, since its children are other tasks
+*/
+type walkTask struct {
+	chain []*Node
+	deep  bool
+}
+
+/*
+GoTraverseContext function traverses nodes which have words with
+a pool of workers. Tree is split to tasks by sizes of subtrees,
+so big subtrees are shared by many workers. If workers is not
+positive, it is count of CPUs. Traversal stops when ctx is done
+or fn returns error, and that error is returned
+*/
+func (s *Streeng) GoTraverseContext(ctx context.Context, workers int, fn func(*Node) error) error {
+	if s == nil || s.root == nil {
+		return ctx.Err()
+	}
+	return runTasks(ctx, workers, splitTasks(plainRoot(s), workers), func(done <-chan struct{}, i int, task walkTask) error {
+		node := task.chain[len(task.chain)-1]
+		if !task.deep {
+			if len(node.words) > 0 {
+				return fn(node)
+			}
+			return nil
+		}
+		return traverseContext(done, node, fn)
+	})
+}
+
+/*
+MatchContext function matches words with given regular expression
+like Match. Tree is split to tasks of GoTraverseContext, so it runs
+on many workers, and it stops when ctx is done. Anchored expressions
+visit only a few nodes, so they and small trees are matched by the
+calling goroutine
+*/
+func (s *Streeng) MatchContext(ctx context.Context, regex string) ([]int, error) {
+	m, err := makeMatcher(regex)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil || s.root == nil {
+		return []int{}, ctx.Err()
+	}
+	root := plainRoot(s)
+	if m.anchored || root.size < matchTerms {
+		results := []int{}
+		m.done = ctx.Done()
+		if !m.walk(root, -1, nil, func(v int) bool {
+			results = append(results, v)
+			return true
+		}) {
+			return nil, ctx.Err()
+		}
+		return results, nil
+	}
+	tasks := splitTasks(root, 0)
+	if len(tasks) == 0 {
+		return []int{}, ctx.Err()
+	}
+	parts := make([][]int, len(tasks))
+	matchers := make(chan *matcher, len(tasks))
+	matchers <- m
+	err = runTasks(ctx, 0, tasks, func(done <-chan struct{}, i int, task walkTask) error {
+		var tm *matcher
+		select {
+		case tm = <-matchers:
+		default:
+			tm, _ = makeMatcher(regex)
+		}
+		defer func() { matchers <- tm }()
+		tm.done = done
+		completed := tm.walkTask(task, func(v int) bool {
+			parts[i] = append(parts[i], v)
+			return true
+		})
+		if !completed {
+			return context.Canceled
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := []int{}
+	for _, v := range parts {
+		results = append(results, v...)
+	}
+	return results, nil
+}
+
+/*
+splitTasks splits the tree under root to tasks. The biggest
+subtree is split to its node and its children until every
+subtree has at most total/(4*workers) terms
+*/
+func splitTasks(root *Node, workers int) []walkTask {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	count := 4 * workers
+	limit := root.size / count
+	if limit < 1 {
+		limit = 1
+	}
+	tasks := []walkTask{}
+	for _, v := range sortedChildren(root) {
+		tasks = append(tasks, walkTask{chain: []*Node{v}, deep: true})
+	}
+	for len(tasks) < 8*count {
+		biggest := -1
+		for k, v := range tasks {
+			node := v.chain[len(v.chain)-1]
+			if v.deep && node.size > limit && len(node.characters) > 0 &&
+				(biggest < 0 || node.size > tasks[biggest].chain[len(tasks[biggest].chain)-1].size) {
+				biggest = k
+			}
+		}
+		if biggest < 0 {
+			break
+		}
+		task := tasks[biggest]
+		node := task.chain[len(task.chain)-1]
+		split := []walkTask{{chain: task.chain, deep: false}}
+		for _, v := range sortedChildren(node) {
+			chain := append(task.chain[:len(task.chain):len(task.chain)], v)
+			split = append(split, walkTask{chain: chain, deep: true})
+		}
+		tasks = append(tasks[:biggest], append(split, tasks[biggest+1:]...)...)
+	}
+	return tasks
+}
+
+/*
+runTasks runs visit for each task on a pool of workers. It returns
+the first error of visit, or error of ctx if ctx is done. Other
+workers see done closed after the first error
+*/
+func runTasks(ctx context.Context, workers int, tasks []walkTask,
+	visit func(done <-chan struct{}, i int, task walkTask) error) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	inner, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var once sync.Once
+	var first error
+	queue := make(chan int)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				if err := visit(inner.Done(), i, tasks[i]); err != nil {
+					once.Do(func() {
+						first = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+feed:
+	for i := range tasks {
+		select {
+		case queue <- i:
+		case <-inner.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return first
+}
+
+// traverseContext calls fn for nodes which have words under node in order
+func traverseContext(done <-chan struct{}, node *Node, fn func(*Node) error) error {
+	select {
+	case <-done:
+		return context.Canceled
+	default:
+	}
+	if len(node.words) > 0 {
+		if err := fn(node); err != nil {
+			return err
+		}
+	}
+	for _, v := range sortedChildren(node) {
+		if err := traverseContext(done, v, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
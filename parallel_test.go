@@ -0,0 +1,156 @@
+package streeng
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGoTraverseContext(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	for _, radix := range []bool{false, true} {
+		opts := []Option{}
+		if radix {
+			opts = append(opts, Radix())
+		}
+		streeng := MakeStreeng(words, opts...)
+		expected := []int{}
+		streeng.Traverse(func(n *Node) {
+			expected = append(expected, n.words[0])
+		})
+		sort.Ints(expected)
+		for _, workers := range []int{0, 1, 3, 16} {
+			var mutex sync.Mutex
+			results := []int{}
+			err := streeng.GoTraverseContext(context.Background(), workers, func(n *Node) error {
+				mutex.Lock()
+				results = append(results, n.words[0])
+				mutex.Unlock()
+				return nil
+			})
+			sort.Ints(results)
+			if err == nil && reflect.DeepEqual(results, expected) {
+				t.Logf("Test Successful: radix: %t \t workers: %d", radix, workers)
+			} else {
+				t.Errorf("Test Fail:\t radix: %t \t workers: %d \t expected: %d \t result: %d",
+					radix, workers, len(expected), len(results))
+			}
+		}
+		for _, task := range splitTasks(streeng.root, 4) {
+			node := task.chain[len(task.chain)-1]
+			if task.deep && node.size > streeng.root.size/16 && len(node.characters) > 0 {
+				t.Errorf("Test Fail:\t radix: %t \t task is big: %d", radix, node.size)
+			}
+		}
+		stop := errors.New("stop")
+		var mutex sync.Mutex
+		calls := 0
+		err := streeng.GoTraverseContext(context.Background(), 4, func(n *Node) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			calls++
+			if calls == 100 {
+				return stop
+			}
+			return nil
+		})
+		if err != stop || calls >= len(expected) {
+			t.Errorf("Test Fail:\t radix: %t \t error: %v \t calls: %d", radix, err, calls)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := streeng.GoTraverseContext(ctx, 4, func(n *Node) error { return nil }); err != context.Canceled {
+			t.Errorf("Test Fail:\t radix: %t \t cancel: %v", radix, err)
+		}
+	}
+}
+
+func TestMatchContext(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	tests := []string{`c..t`, `.*ion`, `^(The)`, `^Mr`, `ness$`, `[0-9]+`, `^a`, `x`, `asdgh`, ``}
+	for _, radix := range []bool{false, true} {
+		opts := []Option{}
+		if radix {
+			opts = append(opts, Radix())
+		}
+		streeng := MakeStreeng(words, opts...)
+		for _, test := range tests {
+			re := regexp.MustCompile(test)
+			expected := []int{}
+			for k, word := range words {
+				if re.MatchString(word) {
+					expected = append(expected, k)
+				}
+			}
+			results, err := streeng.MatchContext(context.Background(), test)
+			sort.Ints(results)
+			if err == nil && reflect.DeepEqual(results, expected) {
+				t.Logf("Test Successful: radix: %t \t regex: %s", radix, test)
+			} else {
+				t.Errorf("Test Fail:\t radix: %t \t regex: %s \t expected: %d \t result: %d",
+					radix, test, len(expected), len(results))
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		time.Sleep(time.Millisecond)
+		if _, err := streeng.MatchContext(ctx, `.*e.*e.*e`); err != context.DeadlineExceeded {
+			t.Errorf("Test Fail:\t radix: %t \t deadline: %v", radix, err)
+		}
+		if _, err := streeng.MatchContext(ctx, `^The`); err != context.DeadlineExceeded {
+			t.Errorf("Test Fail:\t radix: %t \t anchored deadline: %v", radix, err)
+		}
+		cancel()
+	}
+}
+
+func TestMatchContextEmpty(t *testing.T) {
+	for _, opts := range [][]Option{nil, {Radix()}, {DAWG()}} {
+		empty := MakeStreeng([]string{}, opts...)
+		cleaned := MakeStreeng([]string{"cat", "cart"}, opts...)
+		cleaned.Clean()
+		removed := MakeStreeng([]string{"cat", "cart"}, opts...)
+		removed.Remove(0)
+		removed.Remove(1)
+		cases := map[string]*Streeng{"empty": empty, "cleaned": cleaned, "removed": removed}
+		if removed.dawg {
+			delete(cases, "removed")
+		}
+		for name, streeng := range cases {
+			done := make(chan bool)
+			go func() {
+				matched, err1 := streeng.Match(`c.*t`)
+				queried, err2 := streeng.Query(`/c.*t/`)
+				safe, err3 := (&SafeStreeng{streeng: streeng}).Match(`c.*t`)
+				done <- err1 == nil && err2 == nil && err3 == nil &&
+					len(matched) == 0 && len(queried) == 0 && len(safe) == 0
+			}()
+			select {
+			case ok := <-done:
+				if ok {
+					t.Logf("Test Successful: %s", name)
+				} else {
+					t.Errorf("Test Fail:\t %s: words are matched", name)
+				}
+			case <-time.After(time.Second):
+				t.Errorf("Test Fail:\t %s: match does not return", name)
+			}
+		}
+		if _, err := empty.MatchContext(context.Background(), `(`); err == nil {
+			t.Errorf("Test Fail:\t bad regex is matched")
+		}
+	}
+}
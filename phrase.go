@@ -0,0 +1,61 @@
+package streeng
+
+/*
+Phrase function searches given words which follow each other
+and returns positions of the first word. Posting lists of words
+are sorted, so they are merged without maps
+*/
+func (s *Streeng) Phrase(words ...string) []int {
+	if s == nil || len(words) == 0 {
+		return nil
+	}
+	results := s.Search(words[0])
+	for k, v := range words[1:] {
+		postings := s.Search(v)
+		shift := k + 1
+		merged := []int{}
+		i, j := 0, 0
+		for i < len(results) && j < len(postings) {
+			if results[i]+shift < postings[j] {
+				i++
+			} else if results[i]+shift > postings[j] {
+				j++
+			} else {
+				merged = append(merged, results[i])
+				i++
+				j++
+			}
+		}
+		results = merged
+		if len(results) == 0 {
+			return nil
+		}
+	}
+	return append([]int{}, results...)
+}
+
+/*
+Near function returns positions of word a which have word b
+at most window words before or after them
+*/
+func (s *Streeng) Near(a, b string, window int) []int {
+	if s == nil || window < 0 {
+		return nil
+	}
+	first := s.Search(a)
+	second := s.Search(b)
+	results := []int{}
+	j := 0
+	for _, p := range first {
+		for j < len(second) && second[j] < p-window {
+			j++
+		}
+		for k := j; k < len(second) && second[k] <= p+window; k++ {
+			if second[k] != p {
+				results = append(results, p)
+				break
+			}
+		}
+	}
+	return results
+}
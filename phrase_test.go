@@ -0,0 +1,82 @@
+package streeng
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPhrase(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	tests := [][]string{
+		{`Mr.`, `Darcy`},
+		{`in`, `the`, `world`},
+		{`Pride`, `and`, `Prejudice`},
+		{`of`, `the`, `asd`},
+		{`the`},
+	}
+	for _, test := range tests {
+		expected := []int{}
+		for k := range words {
+			i := 0
+			for i < len(test) && k+i < len(words) && words[k+i] == test[i] {
+				i++
+			}
+			if i == len(test) {
+				expected = append(expected, k)
+			}
+		}
+		results := streeng.Phrase(test...)
+		if reflect.DeepEqual(results, expected) || len(results)+len(expected) == 0 {
+			t.Logf("Test Successful: phrase: %v", test)
+		} else {
+			t.Errorf("Test Fail:\t phrase: %v \t expected: %d \t result: %d",
+				test, len(expected), len(results))
+		}
+	}
+}
+
+func TestNear(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	tests := []struct {
+		a, b   string
+		window int
+	}{
+		{`Elizabeth`, `Darcy`, 5},
+		{`the`, `the`, 3},
+		{`Jane`, `Bingley`, 0},
+		{`Jane`, `Bingley`, 10},
+		{`asd`, `the`, 10},
+	}
+	for _, test := range tests {
+		expected := []int{}
+		for k, word := range words {
+			if word != test.a {
+				continue
+			}
+			for i := k - test.window; i <= k+test.window; i++ {
+				if i != k && i >= 0 && i < len(words) && words[i] == test.b {
+					expected = append(expected, k)
+					break
+				}
+			}
+		}
+		results := streeng.Near(test.a, test.b, test.window)
+		if reflect.DeepEqual(results, expected) {
+			t.Logf("Test Successful: near: %s %s %d", test.a, test.b, test.window)
+		} else {
+			t.Errorf("Test Fail:\t near: %s %s %d \t expected: %d \t result: %d",
+				test.a, test.b, test.window, len(expected), len(results))
+		}
+	}
+}
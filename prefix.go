@@ -0,0 +1,85 @@
+package streeng
+
+// Prefix is a struct of a word which is a prefix of an input
+type Prefix struct {
+	Term  string
+	Words []int
+}
+
+/*
+LongestPrefix function returns the longest word which is a
+prefix of input. The tree is walked as far as input goes and
+the last node which has words is remembered
+*/
+func (s *Streeng) LongestPrefix(input string) (string, []int, bool) {
+	runic := []rune(input)
+	term, words, ok := "", []int(nil), false
+	walkPrefixes(s, runic, func(end int, indices []int) {
+		term, words, ok = string(runic[:end]), indices, true
+	})
+	return term, words, ok
+}
+
+/*
+AllPrefixes function returns every word which is a prefix
+of input, from the shortest to the longest
+*/
+func (s *Streeng) AllPrefixes(input string) []Prefix {
+	runic := []rune(input)
+	prefixes := []Prefix{}
+	walkPrefixes(s, runic, func(end int, indices []int) {
+		prefixes = append(prefixes, Prefix{string(runic[:end]), indices})
+	})
+	return prefixes
+}
+
+/*
+walkPrefixes walks runes from root and calls fn with count
+of runes and words for each word which is a prefix of runes
+*/
+func walkPrefixes(s *Streeng, runic []rune, fn func(end int, words []int)) {
+	if s == nil || s.root == nil {
+		return
+	}
+	if s.dawg {
+		rank := 0
+		tempNode := s.root
+		for i, r := range runic {
+			if tempNode.final {
+				rank++
+			}
+			for k, v := range tempNode.characters {
+				if k < r {
+					rank += v.size
+				}
+			}
+			tempNode = tempNode.characters[r]
+			if tempNode == nil {
+				return
+			}
+			if tempNode.final {
+				fn(i+1, s.postings[rank])
+			}
+		}
+		return
+	}
+	tempNode := s.root
+	lenOfValue := len(runic)
+	for i := 0; i < lenOfValue; {
+		child := tempNode.characters[runic[i]]
+		if child == nil {
+			return
+		}
+		i++
+		for _, v := range child.label {
+			if i == lenOfValue || v != runic[i] {
+				return
+			}
+			i++
+		}
+		if len(child.words) > 0 {
+			fn(i, child.words)
+		}
+		tempNode = child
+	}
+}
@@ -0,0 +1,52 @@
+package streeng
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLongestPrefix(t *testing.T) {
+	dictionary := []string{`/`, `/api`, `/api/v1`, `/api/v1/users`, `/apix`, `/static`,
+		`/api`, `/s`, `ü`, `über`}
+	tests := []string{`/api/v1/users/42`, `/api/v2`, `/static/app.js`, `/apix`, `über-cool`,
+		`x`, ``, `/ap`}
+	for _, opt := range []string{"trie", "radix", "dawg"} {
+		opts := []Option{}
+		switch opt {
+		case "radix":
+			opts = append(opts, Radix())
+		case "dawg":
+			opts = append(opts, DAWG())
+		}
+		streeng := MakeStreeng(dictionary, opts...)
+		streeng.Remove(7)
+		for _, test := range tests {
+			expected := []Prefix{}
+			for i := 1; i <= len(test); i++ {
+				if words := streeng.Search(test[:i]); len(words) > 0 {
+					expected = append(expected, Prefix{test[:i], words})
+				}
+			}
+			results := streeng.AllPrefixes(test)
+			term, words, ok := streeng.LongestPrefix(test)
+			longest := len(expected) > 0 && ok &&
+				term == expected[len(expected)-1].Term &&
+				reflect.DeepEqual(words, expected[len(expected)-1].Words)
+			if reflect.DeepEqual(results, expected) && (longest || len(expected) == 0 && !ok) {
+				t.Logf("Test Successful: %s \t input: %s", opt, test)
+			} else {
+				t.Errorf("Test Fail:\t %s \t input: %s \t expected: %v \t result: %v %s",
+					opt, test, expected, results, term)
+			}
+		}
+	}
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	streeng := MakeStreeng(strings.Fields(text))
+	if term, _, ok := streeng.LongestPrefix("Darcyness"); !ok || term != "Darcy" {
+		t.Errorf("Test Fail:\t longest prefix: %s", term)
+	}
+}
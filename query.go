@@ -0,0 +1,389 @@
+package streeng
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError is returned when a query can not be parsed
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("streeng: %s at position %d", e.Msg, e.Pos)
+}
+
+const (
+	tokenWord = iota
+	tokenQuoted
+	tokenRegex
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+	tokenEnd
+)
+
+type queryToken struct {
+	kind  int
+	value string
+	pos   int
+}
+
+// queryNode is a node of query syntax tree
+type queryNode interface {
+	eval(s *Streeng) []int
+}
+
+type (
+	searchNode    struct{ word string }
+	startWithNode struct{ prefix string }
+	endWithNode   struct{ suffix string }
+	matchNode     struct{ regex string }
+	phraseNode    struct{ words []string }
+	andNode       struct{ left, right queryNode }
+	orNode        struct{ left, right queryNode }
+	notNode       struct{ operand queryNode }
+)
+
+/*
+Query function evaluates a boolean query and returns sorted
+word indexes. A query has words, which are searched exactly,
+words like prefix* and *suffix, wildcards like a*b?c, quoted
+"words" and "phrases", /regular expressions/, parentheses and
+AND, OR, NOT operators. Words next to each other are joined with
+AND, and NOT after a word means AND NOT. Reverse tree is built
+if a suffix query needs it
+*/
+func (s *Streeng) Query(expr string) ([]int, error) {
+	tokens, err := lexQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens}
+	node, err2 := p.parseOr()
+	if err2 != nil {
+		return nil, err2
+	}
+	if p.peek().kind != tokenEnd {
+		return nil, &ParseError{p.peek().pos, "unexpected " + tokenName(p.peek())}
+	}
+	if s == nil || s.root == nil {
+		return []int{}, nil
+	}
+	return node.eval(s), nil
+}
+
+func lexQuery(expr string) ([]queryToken, error) {
+	tokens := []queryToken{}
+	for i := 0; i < len(expr); {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, queryToken{tokenOpen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{tokenClose, ")", i})
+			i++
+		case r == '"' || r == '/':
+			value, end, ok := lexQuoted(expr, i, byte(r))
+			if !ok {
+				return nil, &ParseError{i, "unterminated " + string(r)}
+			}
+			kind := tokenQuoted
+			if r == '/' {
+				kind = tokenRegex
+				if _, err := regexp.Compile(value); err != nil {
+					return nil, &ParseError{i, err.Error()}
+				}
+			}
+			tokens = append(tokens, queryToken{kind, value, i})
+			i = end
+		default:
+			start := i
+			for i < len(expr) {
+				r, size = utf8.DecodeRuneInString(expr[i:])
+				if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' {
+					break
+				}
+				i += size
+			}
+			word := expr[start:i]
+			kind := tokenWord
+			switch word {
+			case "AND":
+				kind = tokenAnd
+			case "OR":
+				kind = tokenOr
+			case "NOT":
+				kind = tokenNot
+			}
+			tokens = append(tokens, queryToken{kind, word, start})
+		}
+	}
+	return append(tokens, queryToken{tokenEnd, "", len(expr)}), nil
+}
+
+// lexQuoted reads value between quote characters, backslash escapes a quote
+func lexQuoted(expr string, start int, quote byte) (string, int, bool) {
+	var sb strings.Builder
+	for i := start + 1; i < len(expr); i++ {
+		switch {
+		case expr[i] == '\\' && i+1 < len(expr) && expr[i+1] == quote:
+			sb.WriteByte(quote)
+			i++
+		case expr[i] == quote:
+			return sb.String(), i + 1, true
+		default:
+			sb.WriteByte(expr[i])
+		}
+	}
+	return "", 0, false
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryParser) next() queryToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err2 := p.parseAnd()
+		if err2 != nil {
+			return nil, err2
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().kind {
+		case tokenAnd:
+			p.next()
+		case tokenNot, tokenWord, tokenQuoted, tokenRegex, tokenOpen:
+		default:
+			return left, nil
+		}
+		right, err2 := p.parseNot()
+		if err2 != nil {
+			return nil, err2
+		}
+		left = &andNode{left, right}
+	}
+}
+
+func (p *queryParser) parseNot() (queryNode, error) {
+	if p.peek().kind == tokenNot {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenClose {
+			return nil, &ParseError{p.peek().pos, "expected ) but found " + tokenName(p.peek())}
+		}
+		p.next()
+		return node, nil
+	case tokenQuoted:
+		words := strings.Fields(token.value)
+		if len(words) == 1 && words[0] == token.value {
+			return &searchNode{token.value}, nil
+		}
+		if len(words) == 0 {
+			return nil, &ParseError{token.pos, "empty quoted words"}
+		}
+		return &phraseNode{words}, nil
+	case tokenRegex:
+		return &matchNode{token.value}, nil
+	case tokenWord:
+		return wordNode(token.value), nil
+	}
+	return nil, &ParseError{token.pos, "unexpected " + tokenName(token)}
+}
+
+// wordNode makes node of a word which can have * and ? wildcards
+func wordNode(word string) queryNode {
+	if !strings.ContainsAny(word, "*?") {
+		return &searchNode{word}
+	}
+	inner := strings.Trim(word, "*")
+	if !strings.ContainsAny(inner, "*?") && len(inner) > 0 {
+		if strings.HasPrefix(word, "*") && !strings.HasSuffix(word, "*") {
+			return &endWithNode{inner}
+		}
+		if strings.HasSuffix(word, "*") && !strings.HasPrefix(word, "*") {
+			return &startWithNode{inner}
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, v := range word {
+		switch v {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(v)))
+		}
+	}
+	sb.WriteString("$")
+	return &matchNode{sb.String()}
+}
+
+func tokenName(token queryToken) string {
+	if token.kind == tokenEnd {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", token.value)
+}
+
+func (n *searchNode) eval(s *Streeng) []int {
+	return sortedWords(append([]int{}, s.Search(n.word)...))
+}
+
+func (n *startWithNode) eval(s *Streeng) []int {
+	return sortedWords(s.StartWith(n.prefix))
+}
+
+func (n *endWithNode) eval(s *Streeng) []int {
+	s.lockLazy()
+	if s.reverseRoot == nil {
+		s.ReverseStreeng()
+	}
+	s.unlockLazy()
+	return sortedWords(s.EndWith(n.suffix))
+}
+
+func (n *matchNode) eval(s *Streeng) []int {
+	words, _ := s.Match(n.regex)
+	return sortedWords(words)
+}
+
+func (n *phraseNode) eval(s *Streeng) []int {
+	return sortedWords(s.Phrase(n.words...))
+}
+
+func (n *andNode) eval(s *Streeng) []int {
+	if not, ok := n.right.(*notNode); ok {
+		return differenceWords(n.left.eval(s), not.operand.eval(s))
+	}
+	return intersectWords(n.left.eval(s), n.right.eval(s))
+}
+
+func (n *orNode) eval(s *Streeng) []int {
+	return unionWords(n.left.eval(s), n.right.eval(s))
+}
+
+func (n *notNode) eval(s *Streeng) []int {
+	all := []int{}
+	for k, v := range s.words {
+		if !s.removed[k] && len(v) > 0 {
+			all = append(all, k)
+		}
+	}
+	return differenceWords(all, n.operand.eval(s))
+}
+
+func sortedWords(words []int) []int {
+	if words == nil {
+		return []int{}
+	}
+	sort.Ints(words)
+	return words
+}
+
+func intersectWords(a, b []int) []int {
+	words := []int{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] < b[j] {
+			i++
+		} else if a[i] > b[j] {
+			j++
+		} else {
+			words = append(words, a[i])
+			i++
+			j++
+		}
+	}
+	return words
+}
+
+func unionWords(a, b []int) []int {
+	words := []int{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		if j == len(b) || i < len(a) && a[i] < b[j] {
+			words = append(words, a[i])
+			i++
+		} else if i == len(a) || a[i] > b[j] {
+			words = append(words, b[j])
+			j++
+		} else {
+			words = append(words, a[i])
+			i++
+			j++
+		}
+	}
+	return words
+}
+
+func differenceWords(a, b []int) []int {
+	words := []int{}
+	j := 0
+	for _, v := range a {
+		for j < len(b) && b[j] < v {
+			j++
+		}
+		if j == len(b) || b[j] != v {
+			words = append(words, v)
+		}
+	}
+	return words
+}
@@ -0,0 +1,85 @@
+package streeng
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	first := regexp.MustCompile(`^[a-c]`)
+	wildcard := regexp.MustCompile(`^c.t.*$`)
+	tests := []struct {
+		expr string
+		fn   func(k int, word string) bool
+	}{
+		{`pride AND (prejud* OR *ness) NOT "Mr."`, func(k int, word string) bool {
+			return false
+		}},
+		{`prejud* OR *ness`, func(k int, word string) bool {
+			return strings.HasPrefix(word, "prejud") || strings.HasSuffix(word, "ness")
+		}},
+		{`Mr* NOT "Mr."`, func(k int, word string) bool {
+			return strings.HasPrefix(word, "Mr") && word != "Mr."
+		}},
+		{`*ion AND /^[a-c]/`, func(k int, word string) bool {
+			return strings.HasSuffix(word, "ion") && first.MatchString(word)
+		}},
+		{`NOT (the OR of) AND c?t*`, func(k int, word string) bool {
+			return wildcard.MatchString(word)
+		}},
+		{`"Elizabeth Bennet" OR Jane`, func(k int, word string) bool {
+			return word == "Elizabeth" && k+1 < len(words) && words[k+1] == "Bennet" ||
+				word == "Jane"
+		}},
+	}
+	for _, test := range tests {
+		expected := []int{}
+		for k, word := range words {
+			if test.fn(k, word) {
+				expected = append(expected, k)
+			}
+		}
+		results, err := streeng.Query(test.expr)
+		if err != nil {
+			t.Errorf("Test Fail:\t query: %s \t error: %s", test.expr, err.Error())
+		} else if reflect.DeepEqual(results, expected) {
+			t.Logf("Test Successful: query: %s", test.expr)
+		} else {
+			t.Errorf("Test Fail:\t query: %s \t expected: %d \t result: %d",
+				test.expr, len(expected), len(results))
+		}
+	}
+}
+
+func TestQueryError(t *testing.T) {
+	streeng := MakeStreeng(strings.Fields("This is a text to test"))
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{``, 0},
+		{`a AND`, 5},
+		{`(a OR b`, 7},
+		{`a OR ) b`, 5},
+		{`"text`, 0},
+		{`text /(/`, 5},
+		{`NOT`, 3},
+	}
+	for _, test := range tests {
+		_, err := streeng.Query(test.expr)
+		if e, ok := err.(*ParseError); ok && e.Pos == test.pos {
+			t.Logf("Test Successful: query: %s \t error: %s", test.expr, err.Error())
+		} else {
+			t.Errorf("Test Fail:\t query: %s \t expected position: %d \t error: %v",
+				test.expr, test.pos, err)
+		}
+	}
+}
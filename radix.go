@@ -0,0 +1,159 @@
+package streeng
+
+/*
+addRadixRunes inserts runes under root of a radix tree.
+Edge of a node is split when runes leave it in the middle.
+It returns count of new nodes
+*/
+func addRadixRunes(root *Node, runic []rune, index int) int {
+	lenOfValue := len(runic)
+	if lenOfValue == 0 {
+		return 0
+	}
+	tempNode := root
+	count := 0
+	path := []*Node{root}
+	for i := 0; i < lenOfValue; {
+		child, ok := tempNode.characters[runic[i]]
+		if !ok {
+			n := new(Node)
+			n.characters = make(map[rune]*Node)
+			n.value = runic[i]
+			n.label = append([]rune(nil), runic[i+1:]...)
+			tempNode.characters[n.value] = n
+			tempNode = n
+			path = append(path, n)
+			count++
+			break
+		}
+		i++
+		j := 0
+		for j < len(child.label) && i < lenOfValue && child.label[j] == runic[i] {
+			i++
+			j++
+		}
+		if j < len(child.label) {
+			n := new(Node)
+			n.characters = make(map[rune]*Node)
+			n.value = child.value
+			n.label = append([]rune(nil), child.label[:j]...)
+			n.size = child.size
+			child.value = child.label[j]
+			child.label = append([]rune(nil), child.label[j+1:]...)
+			n.characters[child.value] = child
+			tempNode.characters[n.value] = n
+			child = n
+			count++
+		}
+		tempNode = child
+		path = append(path, child)
+	}
+	tempNode.words = append(tempNode.words, index)
+	tempNode.numberWords++
+	if len(tempNode.words) == 1 {
+		addSize(path, 1)
+	}
+	return count
+}
+
+/*
+removeRadixRunes removes index from the node of runes in a
+radix tree. Nodes which have no words and no children are
+pruned, and a node which has no words and one child is merged
+with its child. It returns whether index was found and
+count of removed nodes
+*/
+func removeRadixRunes(root *Node, runic []rune, index int) (bool, int) {
+	lenOfValue := len(runic)
+	if lenOfValue == 0 {
+		return false, 0
+	}
+	path := []*Node{root}
+	tempNode := root
+	for i := 0; i < lenOfValue; {
+		child, ok := tempNode.characters[runic[i]]
+		if !ok || i+1+len(child.label) > lenOfValue {
+			return false, 0
+		}
+		i++
+		for _, v := range child.label {
+			if v != runic[i] {
+				return false, 0
+			}
+			i++
+		}
+		path = append(path, child)
+		tempNode = child
+	}
+	found := -1
+	for k, v := range tempNode.words {
+		if v == index {
+			found = k
+			break
+		}
+	}
+	if found < 0 {
+		return false, 0
+	}
+	tempNode.words = append(tempNode.words[:found], tempNode.words[found+1:]...)
+	tempNode.numberWords--
+	if len(tempNode.words) > 0 {
+		return true, 0
+	}
+	addSize(path, -1)
+	pruned := 0
+	if len(tempNode.characters) == 0 {
+		parent := path[len(path)-2]
+		delete(parent.characters, tempNode.value)
+		pruned++
+		tempNode = parent
+		if tempNode == root {
+			return true, pruned
+		}
+	}
+	if len(tempNode.words) == 0 && len(tempNode.characters) == 1 {
+		mergeChild(tempNode)
+		pruned++
+	}
+	return true, pruned
+}
+
+// mergeChild merges the only child of node into node
+func mergeChild(node *Node) {
+	for _, child := range node.characters {
+		node.label = append(append(node.label, child.value), child.label...)
+		node.words = append([]int(nil), child.words...)
+		node.numberWords = child.numberWords
+		node.characters = make(map[rune]*Node, len(child.characters))
+		for k, v := range child.characters {
+			node.characters[k] = v
+		}
+		node.size = child.size
+	}
+}
+
+/*
+cursor is a position in a tree. pos is count of runes of node's
+edge which were passed, so a cursor between two nodes of a radix
+tree has pos smaller than length of edge. Root cursor has pos 1
+*/
+type cursor struct {
+	node *Node
+	pos  int
+}
+
+// end reports whether cursor is at the end of node's edge
+func (c cursor) end() bool {
+	return c.pos > len(c.node.label)
+}
+
+// next calls fn for each rune which can follow cursor
+func (c cursor) next(fn func(value rune, next cursor)) {
+	if !c.end() {
+		fn(c.node.label[c.pos-1], cursor{c.node, c.pos + 1})
+		return
+	}
+	for k, v := range c.node.characters {
+		fn(k, cursor{v, 1})
+	}
+}
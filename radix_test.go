@@ -0,0 +1,115 @@
+package streeng
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRadix(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	streeng.ReverseStreeng()
+	radix := MakeStreeng(words, Radix())
+	radix.ReverseStreeng()
+	if radix.NodeCount() >= streeng.NodeCount() ||
+		radix.ReverseNodeCount() >= streeng.ReverseNodeCount() {
+		t.Errorf("Test Fail:\t radix: %d %d \t trie: %d %d",
+			radix.NodeCount(), radix.ReverseNodeCount(),
+			streeng.NodeCount(), streeng.ReverseNodeCount())
+	} else {
+		t.Logf("Test Successful:\t radix: %d %d \t trie: %d %d",
+			radix.NodeCount(), radix.ReverseNodeCount(),
+			streeng.NodeCount(), streeng.ReverseNodeCount())
+	}
+	if radix.Depth() != streeng.Depth() {
+		t.Errorf("Test Fail:\t depth: %d \t expected: %d", radix.Depth(), streeng.Depth())
+	}
+	sorted := func(v []int) []int {
+		v = append([]int{}, v...)
+		sort.Ints(v)
+		return v
+	}
+	tests := []string{`Mrs.`, `Mr`, `the`, `th`, `ness`, `disc`, `ion`, `asd`, `daughte`, `w`}
+	for _, test := range tests {
+		m1, _ := radix.Match(test + `$`)
+		m2, _ := streeng.Match(test + `$`)
+		if !reflect.DeepEqual(radix.Search(test), streeng.Search(test)) ||
+			radix.Contains(test) != streeng.Contains(test) ||
+			!reflect.DeepEqual(sorted(radix.StartWith(test)), sorted(streeng.StartWith(test))) ||
+			!reflect.DeepEqual(sorted(radix.EndWith(test)), sorted(streeng.EndWith(test))) ||
+			!reflect.DeepEqual(sorted(m1), sorted(m2)) ||
+			!reflect.DeepEqual(radix.SearchFuzzy(test, 1), streeng.SearchFuzzy(test, 1)) {
+			t.Errorf("Test Fail:\t word: %s", test)
+		} else {
+			t.Logf("Test Successful: word: %s", test)
+		}
+	}
+	if !reflect.DeepEqual(radix.Terms(), streeng.Terms()) {
+		t.Errorf("Test Fail:\t terms are different")
+	}
+}
+
+func TestRadixAddRemove(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)[:5000]
+	radix := MakeStreeng(words[:2500], Radix())
+	radix.ReverseStreeng()
+	for _, word := range words[2500:] {
+		radix.Add(word)
+	}
+	fresh := MakeStreeng(words, Radix())
+	fresh.ReverseStreeng()
+	if radix.NodeCount() != fresh.NodeCount() ||
+		radix.ReverseNodeCount() != fresh.ReverseNodeCount() {
+		t.Errorf("Test Fail:\t add: %d %d \t expected: %d %d",
+			radix.NodeCount(), radix.ReverseNodeCount(),
+			fresh.NodeCount(), fresh.ReverseNodeCount())
+	}
+	for i := 0; i < 5000; i += 2 {
+		radix.Remove(i)
+	}
+	odd := []string{}
+	for i := 1; i < 5000; i += 2 {
+		odd = append(odd, words[i])
+	}
+	fresh = MakeStreeng(odd, Radix())
+	fresh.ReverseStreeng()
+	if radix.NodeCount() != fresh.NodeCount() ||
+		radix.ReverseNodeCount() != fresh.ReverseNodeCount() ||
+		radix.Depth() != fresh.Depth() {
+		t.Errorf("Test Fail:\t remove: %d %d %d \t expected: %d %d %d",
+			radix.NodeCount(), radix.ReverseNodeCount(), radix.Depth(),
+			fresh.NodeCount(), fresh.ReverseNodeCount(), fresh.Depth())
+	}
+	for _, word := range odd {
+		if len(radix.Search(word)) != len(fresh.Search(word)) ||
+			len(radix.EndWith(word)) != len(fresh.EndWith(word)) {
+			t.Errorf("Test Fail:\t word: %s", word)
+			break
+		}
+	}
+	var buffer bytes.Buffer
+	radix.WriteTo(&buffer)
+	loaded, err := ReadStreeng(&buffer)
+	if err != nil || loaded.NodeCount() != radix.NodeCount() ||
+		len(loaded.StartWith("th")) != len(radix.StartWith("th")) {
+		t.Errorf("Test Fail:\t radix is not read: %v", err)
+	}
+	buffer.Reset()
+	radix.WriteFrozen(&buffer)
+	frozen, err := LoadFrozen(buffer.Bytes())
+	if err != nil || len(frozen.StartWith("th")) != len(radix.StartWith("th")) ||
+		len(frozen.EndWith("s")) != len(radix.EndWith("s")) {
+		t.Errorf("Test Fail:\t radix is not frozen: %v", err)
+	}
+}
@@ -0,0 +1,253 @@
+package streeng
+
+import "sync"
+
+/*
+SafeStreeng is a streeng which can be used by many goroutines.
+Queries hold a read lock, so many readers run together, and
+functions which change the tree hold a write lock. Results are
+copied, so they are not changed by later writes
+*/
+type SafeStreeng struct {
+	mu      sync.RWMutex
+	streeng *Streeng
+}
+
+// MakeSafeStreeng makes a safe streeng with given string array
+func MakeSafeStreeng(words []string, opts ...Option) *SafeStreeng {
+	return &SafeStreeng{streeng: MakeStreeng(words, opts...)}
+}
+
+/*
+View function calls fn with the streeng under read lock.
+fn should not change the streeng or keep its results
+after it returns
+*/
+func (ss *SafeStreeng) View(fn func(*Streeng)) {
+	ss.rlockPlain()
+	defer ss.mu.RUnlock()
+	fn(ss.streeng)
+}
+
+// Update function calls fn with the streeng under write lock
+func (ss *SafeStreeng) Update(fn func(*Streeng)) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	fn(ss.streeng)
+}
+
+// Add function adds given word and returns its index
+func (ss *SafeStreeng) Add(word string) int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.streeng.Add(word)
+}
+
+// Remove function removes word of given index
+func (ss *SafeStreeng) Remove(index int) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.streeng.Remove(index)
+}
+
+// ReverseStreeng function makes reverse tree for EndWith
+func (ss *SafeStreeng) ReverseStreeng() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.streeng.ReverseStreeng()
+}
+
+// BuildSuffixIndex function makes suffix index and returns its count
+func (ss *SafeStreeng) BuildSuffixIndex() int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.streeng.BuildSuffixIndex()
+}
+
+// Terms function calculates terms with frequency and returns a copy
+func (ss *SafeStreeng) Terms() map[string]int {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return copyTerms(ss.streeng.Terms())
+}
+
+// SetWeights function sets weights of terms for Suggest
+func (ss *SafeStreeng) SetWeights(weights map[string]int) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.streeng.SetWeights(copyTerms(weights))
+}
+
+// Search function searches given word
+func (ss *SafeStreeng) Search(word string, fold ...Fold) []int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return copyWords(ss.streeng.Search(word, fold...))
+}
+
+// StartWith function searches words which start with given string
+func (ss *SafeStreeng) StartWith(word string, fold ...Fold) []int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return copyWords(ss.streeng.StartWith(word, fold...))
+}
+
+// EndWith function searches words which end with given string
+func (ss *SafeStreeng) EndWith(word string, fold ...Fold) []int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return copyWords(ss.streeng.EndWith(word, fold...))
+}
+
+// Contains function returns whether or not the word exists
+func (ss *SafeStreeng) Contains(word string, fold ...Fold) bool {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.streeng.Contains(word, fold...)
+}
+
+// ContainsSubstring function searches words which contain given fragment
+func (ss *SafeStreeng) ContainsSubstring(fragment string) []int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return copyWords(ss.streeng.ContainsSubstring(fragment))
+}
+
+// Match function matches words with given regular expression
+func (ss *SafeStreeng) Match(regex string) ([]int, error) {
+	ss.rlockPlain()
+	defer ss.mu.RUnlock()
+	return ss.streeng.Match(regex)
+}
+
+// SearchFuzzy function searches terms within given Levenshtein distance
+func (ss *SafeStreeng) SearchFuzzy(word string, maxDist int) []FuzzyResult {
+	ss.rlockPlain()
+	defer ss.mu.RUnlock()
+	results := ss.streeng.SearchFuzzy(word, maxDist)
+	for k := range results {
+		results[k].Words = copyWords(results[k].Words)
+	}
+	return results
+}
+
+// Phrase function returns positions where given words follow each other
+func (ss *SafeStreeng) Phrase(words ...string) []int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return copyWords(ss.streeng.Phrase(words...))
+}
+
+/*
+Query function evaluates a boolean query. Reverse tree is made
+under write lock if the query needs it and it was not built,
+and plain tree of DAWG option is made like Match
+*/
+func (ss *SafeStreeng) Query(expr string) ([]int, error) {
+	ss.rlockPlain()
+	if ss.streeng.reverseRoot != nil {
+		defer ss.mu.RUnlock()
+		words, err := ss.streeng.Query(expr)
+		return copyWords(words), err
+	}
+	ss.mu.RUnlock()
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	words, err := ss.streeng.Query(expr)
+	return copyWords(words), err
+}
+
+/*
+Suggest function returns k terms which start with given prefix.
+Weights of subtrees are calculated under write lock once
+*/
+func (ss *SafeStreeng) Suggest(prefix string, k int) []Suggestion {
+	ss.mu.RLock()
+	if ss.streeng.best != nil || ss.streeng.bestRanks != nil {
+		defer ss.mu.RUnlock()
+		return ss.streeng.Suggest(prefix, k)
+	}
+	ss.mu.RUnlock()
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.streeng.Suggest(prefix, k)
+}
+
+// LongestPrefix function returns the longest word which is a prefix of input
+func (ss *SafeStreeng) LongestPrefix(input string) (string, []int, bool) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	term, words, ok := ss.streeng.LongestPrefix(input)
+	return term, copyWords(words), ok
+}
+
+// Range function returns terms which are between from and to
+func (ss *SafeStreeng) Range(from, to string) []string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.streeng.Range(from, to)
+}
+
+// Rank function returns count of terms which are smaller than word
+func (ss *SafeStreeng) Rank(word string) int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.streeng.Rank(word)
+}
+
+// Select function returns the term of given rank
+func (ss *SafeStreeng) Select(rank int) string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.streeng.Select(rank)
+}
+
+// Words function returns word of given index
+func (ss *SafeStreeng) Words(index int) string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.streeng.Words(index)
+}
+
+// Len function returns count of words with removed ones
+func (ss *SafeStreeng) Len() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return len(ss.streeng.words)
+}
+
+/*
+rlockPlain takes read lock when plain tree of word graph is made.
+Queries which walk the tree would make it under read lock, so
+it is made under write lock first
+*/
+func (ss *SafeStreeng) rlockPlain() {
+	for {
+		ss.mu.RLock()
+		if !ss.streeng.dawg || ss.streeng.plain != nil {
+			return
+		}
+		ss.mu.RUnlock()
+		ss.mu.Lock()
+		plainRoot(ss.streeng)
+		ss.mu.Unlock()
+	}
+}
+
+func copyWords(words []int) []int {
+	if words == nil {
+		return nil
+	}
+	return append(make([]int, 0, len(words)), words...)
+}
+
+func copyTerms(terms map[string]int) map[string]int {
+	if terms == nil {
+		return nil
+	}
+	copied := make(map[string]int, len(terms))
+	for k, v := range terms {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,110 @@
+package streeng
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+/*
+TestSafeStreeng runs readers and writers together.
+It should be run with -race flag
+*/
+func TestSafeStreeng(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	for _, radix := range []bool{false, true} {
+		opts := []Option{}
+		if radix {
+			opts = append(opts, Radix())
+		}
+		safe := MakeSafeStreeng(words[:20000], opts...)
+		safe.Terms()
+		safe.BuildSuffixIndex()
+		var wg sync.WaitGroup
+		tests := []string{`the`, `Mr`, `Darcy`, `ness`, `disc`, `pride`}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 200; j++ {
+					test := tests[(i+j)%len(tests)]
+					for _, v := range safe.Search(test) {
+						if w := safe.Words(v); w != test && w != "" {
+							t.Errorf("Test Fail:\t search: %s \t word: %s", test, w)
+						}
+					}
+					safe.StartWith(test, FoldCase)
+					safe.EndWith(test)
+					safe.Contains(test)
+					safe.ContainsSubstring(test)
+					safe.SearchFuzzy(test, 1)
+					safe.Phrase(`of`, test)
+					safe.Suggest(test, 5)
+					safe.LongestPrefix(test)
+					safe.Range(test, test+"z")
+					safe.Select(safe.Rank(test))
+					if j%20 == 0 {
+						safe.Match(test + `.*`)
+						safe.Query(test + `* OR *` + test)
+					}
+				}
+			}(i)
+		}
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 500; j++ {
+					index := safe.Add(words[20000+i*500+j])
+					if j%2 == 0 {
+						safe.Remove(index)
+					}
+					if j == 100 {
+						safe.ReverseStreeng()
+					}
+					if j%100 == 0 {
+						safe.Terms()
+						safe.SetWeights(nil)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		live := 0
+		safe.View(func(s *Streeng) {
+			for k := range s.words {
+				if s.Words(k) != "" {
+					live++
+				}
+			}
+		})
+		if live != 20000+500 || safe.Len() != 21000 {
+			t.Errorf("Test Fail:\t radix: %t \t live: %d \t len: %d", radix, live, safe.Len())
+		} else {
+			t.Logf("Test Successful: radix: %t \t live: %d", radix, live)
+		}
+	}
+	safe := MakeSafeStreeng(words[:20000], DAWG())
+	safe.ReverseStreeng()
+	expected, _ := MakeStreeng(words[:20000]).Query(`/c.*t/ OR *ness`)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			safe.Suggest("th", 3)
+			results, err := safe.Query(`/c.*t/ OR *ness`)
+			sort.Ints(results)
+			if err != nil || !reflect.DeepEqual(results, expected) {
+				t.Errorf("Test Fail:\t dawg query: %v \t expected: %d \t result: %d", err, len(expected), len(results))
+			}
+		}()
+	}
+	wg.Wait()
+}
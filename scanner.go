@@ -0,0 +1,133 @@
+package streeng
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Match is a struct of a word found by Scanner in text
+type Match struct {
+	Term   string
+	Offset int
+	Words  []int
+}
+
+/*
+Scanner is an Aho-Corasick automaton of words of a streeng.
+It has its own nodes with failure and output links, so it does
+not see later Add, Remove, Clean or Rollback of the streeng
+*/
+type Scanner struct {
+	root *scanNode
+}
+
+// scanNode is a node of Scanner, term is kept if a word ends at it
+type scanNode struct {
+	characters map[rune]*scanNode
+	term       string
+	words      []int
+	fail       *scanNode
+	output     *scanNode
+}
+
+/*
+Scanner function makes an Aho-Corasick automaton of words which
+are not removed. Automaton has a node for each rune, so radix
+trees and word graphs are not used for it
+*/
+func (s *Streeng) Scanner() *Scanner {
+	root := &scanNode{characters: make(map[rune]*scanNode)}
+	if s != nil {
+		for k, v := range s.words {
+			if !s.removed[k] && len(v) > 0 {
+				root.add(v, k)
+			}
+		}
+	}
+	queue := []*scanNode{}
+	for _, v := range root.characters {
+		v.fail = root
+		queue = append(queue, v)
+	}
+	for len(queue) > 0 {
+		tempNode := queue[0]
+		queue = queue[1:]
+		for r, v := range tempNode.characters {
+			fail := tempNode.fail
+			for fail != root && fail.characters[r] == nil {
+				fail = fail.fail
+			}
+			if next := fail.characters[r]; next != nil {
+				fail = next
+			}
+			v.fail = fail
+			if fail != root && len(fail.words) > 0 {
+				v.output = fail
+			} else {
+				v.output = fail.output
+			}
+			queue = append(queue, v)
+		}
+	}
+	return &Scanner{root: root}
+}
+
+// add adds runes of word with its index under node
+func (node *scanNode) add(word string, index int) {
+	for _, v := range word {
+		next := node.characters[v]
+		if next == nil {
+			next = &scanNode{characters: make(map[rune]*scanNode)}
+			node.characters[v] = next
+		}
+		node = next
+	}
+	if len(node.words) == 0 {
+		node.term = word
+	}
+	node.words = append(node.words, index)
+}
+
+// FindAll function finds every occurrence of words in text
+func (sc *Scanner) FindAll(text string) []Match {
+	matches := []Match{}
+	sc.Scan(strings.NewReader(text), func(m Match) {
+		matches = append(matches, m)
+	})
+	return matches
+}
+
+/*
+Scan function reads runes from r and calls fn for every
+occurrence of words. Matches are reported in order of their
+ends, longer ones first. It returns error of reader except EOF
+*/
+func (sc *Scanner) Scan(r io.Reader, fn func(Match)) error {
+	reader := bufio.NewReader(r)
+	state := sc.root
+	offset := 0
+	for {
+		char, size, err := reader.ReadRune()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		offset += size
+		for state != sc.root && state.characters[char] == nil {
+			state = state.fail
+		}
+		if next := state.characters[char]; next != nil {
+			state = next
+		}
+		tempNode := state
+		if tempNode == sc.root || len(tempNode.words) == 0 {
+			tempNode = tempNode.output
+		}
+		for ; tempNode != nil; tempNode = tempNode.output {
+			fn(Match{tempNode.term, offset - len(tempNode.term), tempNode.words})
+		}
+	}
+}
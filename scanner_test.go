@@ -0,0 +1,86 @@
+package streeng
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	dictionary := []string{`Darcy`, `Mr`, `Mr.`, `Mrs.`, `he`, `she`, `her`, `hers`,
+		`ion`, `tion`, `é`, `pride`, `the`, `asdfg`, `Mr`}
+	text = text[:len(text)/4] + " café "
+	for _, radix := range []bool{false, true} {
+		opts := []Option{}
+		if radix {
+			opts = append(opts, Radix())
+		}
+		streeng := MakeStreeng(dictionary, opts...)
+		streeng.Remove(13)
+		expected := []Match{}
+		for _, term := range dictionary[:13] {
+			words := streeng.Search(term)
+			for i := 0; ; {
+				j := strings.Index(text[i:], term)
+				if j < 0 {
+					break
+				}
+				expected = append(expected, Match{term, i + j, words})
+				i += j + 1
+			}
+		}
+		order := func(matches []Match) {
+			sort.Slice(matches, func(i, j int) bool {
+				if matches[i].Offset != matches[j].Offset {
+					return matches[i].Offset < matches[j].Offset
+				}
+				return matches[i].Term < matches[j].Term
+			})
+		}
+		order(expected)
+		scanner := streeng.Scanner()
+		results := scanner.FindAll(text)
+		order(results)
+		if reflect.DeepEqual(results, expected) {
+			t.Logf("Test Successful: radix: %t \t matches: %d", radix, len(results))
+		} else {
+			t.Errorf("Test Fail:\t radix: %t \t expected: %d \t result: %d",
+				radix, len(expected), len(results))
+		}
+		streamed := 0
+		if err := scanner.Scan(bytes.NewBufferString(text), func(Match) { streamed++ }); err != nil ||
+			streamed != len(expected) {
+			t.Errorf("Test Fail:\t scan: %d \t expected: %d", streamed, len(expected))
+		}
+	}
+}
+
+func TestScannerAdd(t *testing.T) {
+	streeng := MakeStreeng([]string{"ab"})
+	scanner := streeng.Scanner()
+	streeng.Add("abc")
+	results := scanner.FindAll("abcx")
+	if len(results) != 1 || results[0].Term != "ab" || len(streeng.Scanner().FindAll("abcx")) != 2 {
+		t.Errorf("Test Fail:\t matches after add: %v", results)
+	}
+	snap := streeng.Snapshot()
+	scanner = streeng.Scanner()
+	streeng.Clean()
+	if results := scanner.FindAll("xabc"); len(results) != 2 || results[0].Offset != 1 {
+		t.Errorf("Test Fail:\t matches after clean: %v", results)
+	}
+	streeng.Add("x")
+	scanner = streeng.Scanner()
+	streeng.Rollback(snap)
+	if results := scanner.FindAll("xab"); len(results) != 1 || results[0].Term != "x" {
+		t.Errorf("Test Fail:\t matches after rollback: %v", results)
+	} else {
+		t.Logf("Test Successful: scanner after add, clean and rollback")
+	}
+}
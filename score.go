@@ -0,0 +1,107 @@
+package streeng
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Scoring is a relevance function of Score
+type Scoring int
+
+const (
+	// BM25 scores documents with Okapi BM25
+	BM25 Scoring = iota
+	// TFIDF scores documents with term frequency and inverse document frequency
+	TFIDF
+)
+
+// ScoredDoc is a struct of a document and its relevance to a query
+type ScoredDoc struct {
+	Document string
+	Score    float64
+}
+
+// SetScoring function sets relevance function of Score
+func (c *Corpus) SetScoring(scoring Scoring) {
+	c.scoring = scoring
+}
+
+/*
+SetBM25 function sets k1 and b parameters of BM25. k1 saturates
+term frequency and b normalizes it by document length, defaults
+are 1.2 and 0.75
+*/
+func (c *Corpus) SetBM25(k1, b float64) {
+	c.k1, c.b = k1, b
+}
+
+/*
+Score function ranks documents by relevance to query terms.
+A term which ends with * is expanded to all terms which start
+with it and every expanded term is scored with its own document
+frequency. Documents are sorted by score, then by id
+*/
+func (c *Corpus) Score(query []string) []ScoredDoc {
+	scores := make(map[int]float64)
+	for _, q := range query {
+		postings := c.postings(q)
+		terms := make([]string, 0, len(postings))
+		for term := range postings {
+			terms = append(terms, term)
+		}
+		sort.Strings(terms)
+		for _, term := range terms {
+			df := c.df[term]
+			if df == 0 {
+				continue
+			}
+			tf := make(map[int]int)
+			for _, v := range postings[term] {
+				tf[c.document(v)]++
+			}
+			for i, f := range tf {
+				scores[i] += c.weight(f, df, c.docs[i].length)
+			}
+		}
+	}
+	docs := make([]ScoredDoc, 0, len(scores))
+	for i, score := range scores {
+		docs = append(docs, ScoredDoc{c.docs[i].id, score})
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].Score != docs[j].Score {
+			return docs[i].Score > docs[j].Score
+		}
+		return docs[i].Document < docs[j].Document
+	})
+	return docs
+}
+
+// postings returns word indexes of query term grouped by terms
+func (c *Corpus) postings(q string) map[string][]int {
+	postings := make(map[string][]int)
+	if len(q) > 1 && strings.HasSuffix(q, "*") {
+		for _, v := range c.streeng.StartWith(q[:len(q)-1]) {
+			term := c.streeng.words[v]
+			postings[term] = append(postings[term], v)
+		}
+		return postings
+	}
+	if words := c.streeng.Search(q); len(words) > 0 {
+		postings[q] = words
+	}
+	return postings
+}
+
+// weight returns score of a term which occurs tf times in a document
+func (c *Corpus) weight(tf, df, length int) float64 {
+	n := float64(len(c.ids))
+	f := float64(tf)
+	if c.scoring == TFIDF {
+		return f * math.Log(1+n/float64(df))
+	}
+	idf := math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+	avg := float64(c.length) / n
+	return idf * f * (c.k1 + 1) / (f + c.k1*(1-c.b+c.b*float64(length)/avg))
+}
@@ -0,0 +1,111 @@
+package streeng
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func expectedScore(docs map[string][]string, query []string, scoring Scoring, k1, b float64) []ScoredDoc {
+	n := float64(len(docs))
+	length := 0
+	for _, tokens := range docs {
+		length += len(tokens)
+	}
+	avg := float64(length) / n
+	scores := make(map[string]float64)
+	for _, q := range query {
+		match := func(word string) bool { return word == q }
+		if len(q) > 1 && strings.HasSuffix(q, "*") {
+			match = func(word string) bool { return strings.HasPrefix(word, q[:len(q)-1]) }
+		}
+		tf := make(map[string]map[string]int)
+		for id, tokens := range docs {
+			for _, word := range tokens {
+				if match(word) {
+					if tf[word] == nil {
+						tf[word] = make(map[string]int)
+					}
+					tf[word][id]++
+				}
+			}
+		}
+		for _, counts := range tf {
+			df := float64(len(counts))
+			for id, count := range counts {
+				f := float64(count)
+				if scoring == TFIDF {
+					scores[id] += f * math.Log(1+n/df)
+					continue
+				}
+				idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+				scores[id] += idf * f * (k1 + 1) / (f + k1*(1-b+b*float64(len(docs[id]))/avg))
+			}
+		}
+	}
+	results := []ScoredDoc{}
+	for id, score := range scores {
+		results = append(results, ScoredDoc{id, score})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Document < results[j].Document
+	})
+	return results
+}
+
+func TestScore(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	chapters := strings.Split(text, "Chapter ")
+	corpus := MakeCorpus()
+	docs := make(map[string][]string)
+	for k, v := range chapters {
+		id := fmt.Sprintf("chapter-%d", k)
+		docs[id] = strings.Fields(v)
+		corpus.AddDocument(id, docs[id])
+	}
+	corpus.RemoveDocument("chapter-3")
+	delete(docs, "chapter-3")
+	corpus.AddDocument("chapter-5", strings.Fields(chapters[6]))
+	docs["chapter-5"] = strings.Fields(chapters[6])
+	tests := [][]string{
+		{`Darcy`},
+		{`pride`, `prejudice`},
+		{`Wick*`, `Lydia`},
+		{`disc*`},
+		{`asd`},
+		{`the`, `the`},
+	}
+	params := []struct {
+		scoring Scoring
+		k1, b   float64
+	}{{BM25, 1.2, 0.75}, {BM25, 2, 0}, {TFIDF, 0, 0}}
+	for _, p := range params {
+		corpus.SetScoring(p.scoring)
+		if p.scoring == BM25 {
+			corpus.SetBM25(p.k1, p.b)
+		}
+		for _, test := range tests {
+			results := corpus.Score(test)
+			expected := expectedScore(docs, test, p.scoring, p.k1, p.b)
+			ok := len(results) == len(expected)
+			for i := 0; ok && i < len(results); i++ {
+				ok = results[i].Document == expected[i].Document &&
+					math.Abs(results[i].Score-expected[i].Score) < 1e-9
+			}
+			if ok {
+				t.Logf("Test Successful: query: %v", test)
+			} else {
+				t.Errorf("Test Fail:\t query: %v \t expected: %v \t result: %v",
+					test, expected, results)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package streeng
+
+import "iter"
+
+/*
+AllTerms function returns an iterator over distinct terms and
+their words in order. Terms are made while the tree is walked,
+so the walk stops when the loop breaks
+*/
+func (s *Streeng) AllTerms() iter.Seq2[string, []int] {
+	return func(yield func(string, []int) bool) {
+		if s != nil && s.root != nil {
+			eachTerm(s, yield)
+		}
+	}
+}
+
+// PrefixSeq function returns an iterator over words which start with prefix
+func (s *Streeng) PrefixSeq(prefix string) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		runic := []rune(prefix)
+		if s == nil || s.root == nil || len(runic) == 0 {
+			return
+		}
+		if s.dawg {
+			rank, tempNode := dawgRank(s.root, runic)
+			if tempNode != nil {
+				yieldPostings(s.postings[rank:rank+tempNode.size], yield)
+			}
+			return
+		}
+		if tempNode, _ := descend(s.root, runic); tempNode != nil {
+			walkWords(tempNode, yield)
+		}
+	}
+}
+
+/*
+SuffixSeq function returns an iterator over words which end
+with suffix. Reverse tree should be made like EndWith
+*/
+func (s *Streeng) SuffixSeq(suffix string) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		runic := []rune(suffix)
+		if s == nil || s.reverseRoot == nil || len(runic) == 0 {
+			return
+		}
+		if tempNode, _ := descend(s.reverseRoot, reverseRunes(runic)); tempNode != nil {
+			walkWords(tempNode, yield)
+		}
+	}
+}
+
+/*
+MatchSeq function returns an iterator over words which match
+given regular expression. Regular expression runs alongside
+the tree like Match, and the walk stops when the loop breaks
+*/
+func (s *Streeng) MatchSeq(regex string) (iter.Seq[int], error) {
+	if _, err := makeMatcher(regex); err != nil {
+		return nil, err
+	}
+	return func(yield func(int) bool) {
+		if s != nil && s.root != nil {
+			m, _ := makeMatcher(regex)
+			m.walk(plainRoot(s), -1, nil, yield)
+		}
+	}, nil
+}
+
+func yieldPostings(postings [][]int, yield func(int) bool) {
+	for _, words := range postings {
+		for _, v := range words {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
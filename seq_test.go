@@ -0,0 +1,95 @@
+package streeng
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	for _, opt := range []string{"trie", "radix", "dawg"} {
+		opts := []Option{}
+		switch opt {
+		case "radix":
+			opts = append(opts, Radix())
+		case "dawg":
+			opts = append(opts, DAWG())
+		}
+		streeng := MakeStreeng(words, opts...)
+		streeng.ReverseStreeng()
+		terms := []string{}
+		for term, indices := range streeng.AllTerms() {
+			if !reflect.DeepEqual(indices, streeng.Search(term)) {
+				t.Errorf("Test Fail:\t %s \t term: %s", opt, term)
+			}
+			terms = append(terms, term)
+		}
+		if !sort.StringsAreSorted(terms) || len(terms) != streeng.Rank("\U0010FFFF") {
+			t.Errorf("Test Fail:\t %s \t terms: %d", opt, len(terms))
+		}
+		for _, test := range []string{`th`, `Mr`, `disc`, `asd`, `a`} {
+			prefix := slices.Collect(streeng.PrefixSeq(test))
+			if !reflect.DeepEqual(prefix, streeng.StartWith(test)) &&
+				!(len(prefix) == 0 && len(streeng.StartWith(test)) == 0) {
+				t.Errorf("Test Fail:\t %s \t prefix: %s", opt, test)
+			}
+			suffix := slices.Collect(streeng.SuffixSeq(test))
+			expected := streeng.EndWith(test)
+			sort.Ints(suffix)
+			sort.Ints(expected)
+			if len(suffix) != len(expected) || len(suffix) > 0 && !reflect.DeepEqual(suffix, expected) {
+				t.Errorf("Test Fail:\t %s \t suffix: %s", opt, test)
+			}
+			count := 0
+			for range streeng.PrefixSeq(test) {
+				count++
+				if count == 20 {
+					break
+				}
+			}
+			if count != min(20, len(prefix)) {
+				t.Errorf("Test Fail:\t %s \t prefix break: %d", opt, count)
+			}
+		}
+		if opt == "dawg" {
+			continue
+		}
+		for _, test := range []string{`c..t`, `.*ion`, `^(The)`, `[0-9]+`, `asdgh`} {
+			seq, err := streeng.MatchSeq(test)
+			if err != nil {
+				t.Errorf("Test Fail:\t regex: %s\t error in MatchSeq", test)
+				continue
+			}
+			matched := slices.Collect(seq)
+			expected, _ := streeng.Match(test)
+			sort.Ints(matched)
+			sort.Ints(expected)
+			if len(matched) != len(expected) || len(matched) > 0 && !reflect.DeepEqual(matched, expected) {
+				t.Errorf("Test Fail:\t %s \t regex: %s \t expected: %d \t result: %d",
+					opt, test, len(expected), len(matched))
+			} else {
+				t.Logf("Test Successful: %s \t regex: %s", opt, test)
+			}
+			count := 0
+			for range seq {
+				count++
+				if count == 5 {
+					break
+				}
+			}
+			if count != min(5, len(expected)) {
+				t.Errorf("Test Fail:\t %s \t regex break: %d", opt, count)
+			}
+		}
+	}
+	if _, err := MakeStreeng(words).MatchSeq(`a(`); err == nil {
+		t.Errorf("Test Fail:\t invalid regex is compiled")
+	}
+}
@@ -0,0 +1,352 @@
+package streeng
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+const (
+	formatMagic   = "STRG"
+	formatVersion = 2
+)
+
+const (
+	flagReverse = 1 << iota
+	flagTerms
+	flagRadix
+	flagDAWG
+	flagText
+)
+
+// FormatError is returned when streeng data is truncated or corrupt
+type FormatError struct {
+	Offset int
+	Reason string
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("streeng: %s at offset %d", e.Reason, e.Offset)
+}
+
+/*
+WriteTo function writes streeng to w in binary format.
+Forward tree, reverse tree, words, terms, tokens, surfaces and
+offsets are written, suffix index is not. Word graph of DAWG
+option is not written either, it is built again while reading.
+Data ends with a CRC-32 checksum
+*/
+func (s *Streeng) WriteTo(w io.Writer) (int64, error) {
+	data := []byte(formatMagic)
+	flags := byte(0)
+	if s.reverseRoot != nil {
+		flags |= flagReverse
+	}
+	if s.terms != nil {
+		flags |= flagTerms
+	}
+	if s.radix {
+		flags |= flagRadix
+	}
+	if s.dawg {
+		flags |= flagDAWG
+	}
+	if s.surfaces != nil {
+		flags |= flagText
+	}
+	data = append(data, formatVersion, flags)
+	data = binary.AppendUvarint(data, uint64(s.depth))
+	data = binary.AppendVarint(data, int64(s.lastToken))
+	data = binary.AppendUvarint(data, uint64(len(s.words)))
+	for _, v := range s.words {
+		data = binary.AppendUvarint(data, uint64(len(v)))
+		data = append(data, v...)
+	}
+	removed := []int{}
+	for k := range s.removed {
+		removed = append(removed, k)
+	}
+	sort.Ints(removed)
+	data = binary.AppendUvarint(data, uint64(len(removed)))
+	for _, v := range removed {
+		data = binary.AppendUvarint(data, uint64(v))
+	}
+	if !s.dawg {
+		data = appendNode(data, s.root)
+	}
+	if s.reverseRoot != nil {
+		data = appendNode(data, s.reverseRoot)
+	}
+	if s.terms != nil {
+		terms := []string{}
+		for k := range s.terms {
+			terms = append(terms, k)
+		}
+		sort.Strings(terms)
+		data = binary.AppendUvarint(data, uint64(len(terms)))
+		for _, v := range terms {
+			data = binary.AppendUvarint(data, uint64(len(v)))
+			data = append(data, v...)
+			data = binary.AppendUvarint(data, uint64(s.terms[v]))
+		}
+		data = binary.AppendUvarint(data, uint64(len(s.tokens)))
+		for _, v := range s.tokens {
+			data = binary.AppendVarint(data, int64(v))
+		}
+	}
+	if s.surfaces != nil {
+		for k, v := range s.surfaces {
+			data = binary.AppendUvarint(data, uint64(len(v)))
+			data = append(data, v...)
+			data = binary.AppendVarint(data, int64(s.offsets[k]))
+		}
+	}
+	data = binary.LittleEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+/*
+ReadStreeng function reads a streeng which was written by WriteTo.
+Data of version 1, which has no radix trees, is read too.
+If data is truncated or corrupt, it returns *FormatError
+*/
+func ReadStreeng(r io.Reader) (*Streeng, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	header := len(formatMagic) + 2
+	if len(data) < header+4 {
+		return nil, &FormatError{len(data), "truncated data"}
+	}
+	if string(data[:len(formatMagic)]) != formatMagic {
+		return nil, &FormatError{0, "invalid magic"}
+	}
+	body := len(data) - 4
+	if crc32.ChecksumIEEE(data[:body]) != binary.LittleEndian.Uint32(data[body:]) {
+		return nil, &FormatError{body, "checksum mismatch"}
+	}
+	version := data[len(formatMagic)]
+	if version < 1 || version > formatVersion {
+		return nil, &FormatError{len(formatMagic),
+			fmt.Sprintf("unsupported version %d", data[len(formatMagic)])}
+	}
+	flags := data[len(formatMagic)+1]
+	d := &decoder{data: data[:body], offset: header, labels: version >= 2}
+	s := new(Streeng)
+	s.radix = flags&flagRadix != 0
+	s.depth = d.int()
+	s.lastToken = int(d.varint())
+	s.words = make([]string, d.count())
+	for k := range s.words {
+		s.words[k] = d.string()
+	}
+	if n := d.count(); n > 0 {
+		s.removed = make(map[int]bool)
+		for i := 0; i < n; i++ {
+			s.removed[d.index(len(s.words))] = true
+		}
+	}
+	s.dawg = flags&flagDAWG != 0
+	s.nodeCount = 1
+	if s.dawg {
+		buildDAWG(s)
+	} else {
+		s.root = d.node(s, 0, &s.nodeCount)
+	}
+	s.reverseCount = -1
+	if flags&flagReverse != 0 {
+		s.reverseCount = 0
+		s.reverseRoot = d.node(s, 0, &s.reverseCount)
+	}
+	if flags&flagTerms != 0 {
+		n := d.count()
+		s.terms = make(map[string]int)
+		for i := 0; i < n; i++ {
+			term := d.string()
+			s.terms[term] = d.int()
+		}
+		s.tokens = make([]int, d.count())
+		for k := range s.tokens {
+			s.tokens[k] = int(d.varint())
+		}
+		if d.err == nil && len(s.tokens) != len(s.words) {
+			d.fail("tokens do not match words")
+		}
+	}
+	if flags&flagText != 0 {
+		s.surfaces = make([]string, len(s.words))
+		s.offsets = make([]int, len(s.words))
+		for k := range s.surfaces {
+			s.surfaces[k] = d.string()
+			s.offsets[k] = int(d.varint())
+		}
+	}
+	if d.err == nil && d.offset != body {
+		d.fail("unexpected trailing data")
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	s.rate = float64(len(s.words)-len(s.removed)) / float64(s.nodeCount)
+	return s, nil
+}
+
+// appendNode appends node and its children in preorder
+func appendNode(data []byte, node *Node) []byte {
+	data = binary.AppendUvarint(data, uint64(node.value))
+	data = binary.AppendUvarint(data, uint64(len(node.label)))
+	for _, v := range node.label {
+		data = binary.AppendUvarint(data, uint64(v))
+	}
+	data = binary.AppendUvarint(data, uint64(len(node.words)))
+	prev := 0
+	for _, v := range node.words {
+		data = binary.AppendUvarint(data, uint64(v-prev))
+		prev = v
+	}
+	keys := []rune{}
+	for k := range node.characters {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	data = binary.AppendUvarint(data, uint64(len(keys)))
+	for _, v := range keys {
+		data = appendNode(data, node.characters[v])
+	}
+	return data
+}
+
+// decoder reads values of binary format and keeps first error
+type decoder struct {
+	data   []byte
+	offset int
+	labels bool
+	err    *FormatError
+}
+
+func (d *decoder) fail(reason string) {
+	if d.err == nil {
+		d.err = &FormatError{d.offset, reason}
+	}
+	d.offset = len(d.data)
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data[d.offset:])
+	if n <= 0 {
+		d.fail("invalid varint")
+		return 0
+	}
+	d.offset += n
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data[d.offset:])
+	if n <= 0 {
+		d.fail("invalid varint")
+		return 0
+	}
+	d.offset += n
+	return v
+}
+
+func (d *decoder) int() int {
+	v := d.uvarint()
+	if v > 1<<31-1 {
+		d.fail("value out of range")
+		return 0
+	}
+	return int(v)
+}
+
+// count reads length of a list which can not exceed remaining data
+func (d *decoder) count() int {
+	v := d.uvarint()
+	if v > uint64(len(d.data)-d.offset) {
+		d.fail("length out of range")
+		return 0
+	}
+	return int(v)
+}
+
+func (d *decoder) index(max int) int {
+	v := d.uvarint()
+	if v >= uint64(max) {
+		d.fail("index out of range")
+		return 0
+	}
+	return int(v)
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	v := string(d.data[d.offset : d.offset+n])
+	d.offset += n
+	return v
+}
+
+// node reads a node and its children, count is increased by each child
+func (d *decoder) node(s *Streeng, level int, count *int) *Node {
+	if level > s.depth {
+		d.fail("tree is deeper than depth")
+		return nil
+	}
+	n := new(Node)
+	n.characters = make(map[rune]*Node)
+	n.value = rune(d.uvarint())
+	if d.labels {
+		if lenOfLabel := d.count(); lenOfLabel > 0 {
+			n.label = make([]rune, lenOfLabel)
+			for k := range n.label {
+				n.label[k] = rune(d.uvarint())
+			}
+		}
+	}
+	if lenOfWords := d.count(); lenOfWords > 0 {
+		n.words = make([]int, lenOfWords)
+		prev := 0
+		for k := range n.words {
+			delta := d.uvarint()
+			if k > 0 && delta == 0 {
+				d.fail("word indexes are not increasing")
+				return nil
+			}
+			if delta >= uint64(len(s.words)-prev) {
+				d.fail("word index out of range")
+				return nil
+			}
+			prev += int(delta)
+			n.words[k] = prev
+		}
+		n.numberWords = lenOfWords
+	}
+	lenOfChildren := d.count()
+	for i := 0; i < lenOfChildren && d.err == nil; i++ {
+		child := d.node(s, level+1, count)
+		if child == nil {
+			return nil
+		}
+		*count++
+		n.characters[child.value] = child
+		n.size += child.size
+	}
+	if len(n.words) > 0 {
+		n.size++
+	}
+	return n
+}
@@ -0,0 +1,98 @@
+package streeng
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWriteRead(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words)
+	streeng.ReverseStreeng()
+	streeng.Terms()
+	streeng.Remove(10)
+	var buffer bytes.Buffer
+	if _, err := streeng.WriteTo(&buffer); err != nil {
+		t.Errorf("Test Fail:\t WriteTo Error: %s", err.Error())
+	}
+	loaded, err := ReadStreeng(bytes.NewReader(buffer.Bytes()))
+	if err != nil {
+		t.Fatalf("Test Fail:\t ReadStreeng Error: %s", err.Error())
+	}
+	if loaded.NodeCount() != streeng.NodeCount() ||
+		loaded.ReverseNodeCount() != streeng.ReverseNodeCount() ||
+		loaded.Depth() != streeng.Depth() || loaded.Rate() != streeng.Rate() {
+		t.Errorf("Test Fail:\t counts: %d %d \t expected: %d %d",
+			loaded.NodeCount(), loaded.ReverseNodeCount(),
+			streeng.NodeCount(), streeng.ReverseNodeCount())
+	}
+	if !reflect.DeepEqual(loaded.TermList(), streeng.TermList()) ||
+		!reflect.DeepEqual(loaded.TokenList(), streeng.TokenList()) {
+		t.Errorf("Test Fail:\t terms and tokens are different")
+	}
+	tests := []string{`Mrs.`, `the`, `ness`, `disc`, `ion`, `asd`, words[10]}
+	for _, test := range tests {
+		sorted := func(v []int) []int {
+			sort.Ints(v)
+			return v
+		}
+		if !reflect.DeepEqual(loaded.Search(test), streeng.Search(test)) ||
+			!reflect.DeepEqual(sorted(loaded.StartWith(test)), sorted(streeng.StartWith(test))) ||
+			!reflect.DeepEqual(sorted(loaded.EndWith(test)), sorted(streeng.EndWith(test))) {
+			t.Errorf("Test Fail:\t word: %s", test)
+		}
+	}
+	loaded.Terms()
+	if !reflect.DeepEqual(loaded.TermList(), streeng.TermList()) {
+		t.Errorf("Test Fail:\t terms of loaded streeng are different")
+	}
+}
+
+func TestReadCorrupt(t *testing.T) {
+	streeng := MakeStreeng(strings.Fields("This is a text to test"))
+	var buffer bytes.Buffer
+	streeng.WriteTo(&buffer)
+	data := buffer.Bytes()
+	tests := [][]byte{
+		nil,
+		data[:5],
+		data[:len(data)/2],
+		data[:len(data)-1],
+		append([]byte("XXXX"), data[4:]...),
+	}
+	for i := 6; i < len(data); i++ {
+		corrupt := append([]byte{}, data...)
+		corrupt[i] ^= 0xff
+		tests = append(tests, corrupt)
+	}
+	// tree of words "a" "a" whose node of "a" has given word deltas
+	checksummed := func(deltas ...uint64) []byte {
+		body := append([]byte(formatMagic), formatVersion, 0, 1, 0, 2, 1, 'a', 1, 'a', 0)
+		body = append(body, 0, 0, 0, 1, 'a', 0, byte(len(deltas)))
+		for _, v := range deltas {
+			body = binary.AppendUvarint(body, v)
+		}
+		body = append(body, 0)
+		return binary.LittleEndian.AppendUint32(body, crc32.ChecksumIEEE(body))
+	}
+	if loaded, err := ReadStreeng(bytes.NewReader(checksummed(0, 1))); err != nil ||
+		!reflect.DeepEqual(loaded.Search("a"), []int{0, 1}) {
+		t.Errorf("Test Fail:\t checksummed data: %v", err)
+	}
+	tests = append(tests, checksummed(0, 1<<63+5), checksummed(1, 0), checksummed(2))
+	for k, test := range tests {
+		_, err := ReadStreeng(bytes.NewReader(test))
+		if _, ok := err.(*FormatError); !ok {
+			t.Errorf("Test Fail:\t data %d \t error: %v", k, err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package streeng
+
+import (
+	"iter"
+	"sort"
+)
+
+// SetTerm is a struct of a term with its frequencies in both streengs
+type SetTerm struct {
+	Term  string
+	Left  int
+	Right int
+}
+
+type setOp int
+
+const (
+	setUnion setOp = iota
+	setIntersect
+	setDifference
+	setSymmetricDifference
+)
+
+/*
+Union function returns an iterator over terms which are
+in a or b in order
+*/
+func Union(a, b *Streeng) iter.Seq[SetTerm] {
+	return setTerms(a, b, setUnion)
+}
+
+/*
+Intersect function returns an iterator over terms which are
+in both a and b in order
+*/
+func Intersect(a, b *Streeng) iter.Seq[SetTerm] {
+	return setTerms(a, b, setIntersect)
+}
+
+/*
+Difference function returns an iterator over terms which are
+in a but not in b in order
+*/
+func Difference(a, b *Streeng) iter.Seq[SetTerm] {
+	return setTerms(a, b, setDifference)
+}
+
+/*
+SymmetricDifference function returns an iterator over terms
+which are in only one of a and b in order
+*/
+func SymmetricDifference(a, b *Streeng) iter.Seq[SetTerm] {
+	return setTerms(a, b, setSymmetricDifference)
+}
+
+/*
+setTerms walks trees of a and b in lockstep rune by rune,
+so radix trees and plain trees can be compared. Subtrees
+which can not have a result term are not visited, and the
+walk stops when the loop breaks
+*/
+func setTerms(a, b *Streeng, op setOp) iter.Seq[SetTerm] {
+	return func(yield func(SetTerm) bool) {
+		var ca, cb *cursor
+		if a != nil && a.root != nil {
+			ca = &cursor{a.root, 1}
+		}
+		if b != nil && b.root != nil {
+			cb = &cursor{b.root, 1}
+		}
+		setChild(a, b, ca, cb, nil, op, yield)
+	}
+}
+
+// setChild returns false when yield stops the walk
+func setChild(a, b *Streeng, ca, cb *cursor, path []rune, op setOp, yield func(SetTerm) bool) bool {
+	if ca == nil && (cb == nil || op == setIntersect || op == setDifference) {
+		return true
+	}
+	if cb == nil && op == setIntersect {
+		return true
+	}
+	if len(path) > 0 {
+		left, right := setFreq(a, ca, path), setFreq(b, cb, path)
+		var ok bool
+		switch op {
+		case setUnion:
+			ok = left > 0 || right > 0
+		case setIntersect:
+			ok = left > 0 && right > 0
+		case setDifference:
+			ok = left > 0 && right == 0
+		case setSymmetricDifference:
+			ok = (left > 0) != (right > 0)
+		}
+		if ok && !yield(SetTerm{string(path), left, right}) {
+			return false
+		}
+	}
+	children := make(map[rune]*[2]*cursor)
+	if ca != nil {
+		ca.next(func(value rune, next cursor) {
+			children[value] = &[2]*cursor{&next, nil}
+		})
+	}
+	if cb != nil {
+		cb.next(func(value rune, next cursor) {
+			if pair := children[value]; pair != nil {
+				pair[1] = &next
+			} else {
+				children[value] = &[2]*cursor{nil, &next}
+			}
+		})
+	}
+	runes := make([]rune, 0, len(children))
+	for k := range children {
+		runes = append(runes, k)
+	}
+	sort.Slice(runes, func(i, j int) bool {
+		return runes[i] < runes[j]
+	})
+	for _, k := range runes {
+		pair := children[k]
+		if !setChild(a, b, pair[0], pair[1], append(path[:len(path):len(path)], k), op, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// setFreq returns frequency of term which ends at cursor
+func setFreq(s *Streeng, c *cursor, path []rune) int {
+	if c == nil || !c.end() || !isTerm(s, c.node) {
+		return 0
+	}
+	if s.dawg {
+		return len(dawgSearch(s, path))
+	}
+	return len(c.node.words)
+}
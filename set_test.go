@@ -0,0 +1,79 @@
+package streeng
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	chapters := strings.Split(text, "Chapter ")
+	left, right := strings.Fields(chapters[1]), strings.Fields(chapters[2])
+	counts := [2]map[string]int{{}, {}}
+	for _, word := range left {
+		counts[0][word]++
+	}
+	for _, word := range right {
+		counts[1][word]++
+	}
+	union := []string{}
+	for k := range counts[0] {
+		union = append(union, k)
+	}
+	for k := range counts[1] {
+		if counts[0][k] == 0 {
+			union = append(union, k)
+		}
+	}
+	sort.Strings(union)
+	expected := func(ok func(l, r int) bool) []SetTerm {
+		terms := []SetTerm{}
+		for _, v := range union {
+			if ok(counts[0][v], counts[1][v]) {
+				terms = append(terms, SetTerm{v, counts[0][v], counts[1][v]})
+			}
+		}
+		return terms
+	}
+	kinds := [][2]string{{"trie", "trie"}, {"radix", "trie"}, {"trie", "dawg"}, {"radix", "radix"}}
+	build := func(kind string, words []string) *Streeng {
+		switch kind {
+		case "radix":
+			return MakeStreeng(words, Radix())
+		case "dawg":
+			return MakeStreeng(words, DAWG())
+		}
+		return MakeStreeng(words)
+	}
+	for _, kind := range kinds {
+		a, b := build(kind[0], left), build(kind[1], right)
+		if !reflect.DeepEqual(slices.Collect(Union(a, b)), expected(func(l, r int) bool { return true })) ||
+			!reflect.DeepEqual(slices.Collect(Intersect(a, b)), expected(func(l, r int) bool { return l > 0 && r > 0 })) ||
+			!reflect.DeepEqual(slices.Collect(Difference(a, b)), expected(func(l, r int) bool { return r == 0 })) ||
+			!reflect.DeepEqual(slices.Collect(SymmetricDifference(a, b)), expected(func(l, r int) bool { return l == 0 || r == 0 })) {
+			t.Errorf("Test Fail:\t %s %s", kind[0], kind[1])
+		} else {
+			t.Logf("Test Successful: %s %s", kind[0], kind[1])
+		}
+	}
+	if len(slices.Collect(Union(nil, MakeStreeng(left)))) != len(counts[0]) ||
+		len(slices.Collect(Intersect(MakeStreeng(left), nil))) != 0 {
+		t.Errorf("Test Fail:\t nil streeng")
+	}
+	terms := []SetTerm{}
+	for term := range Union(MakeStreeng(left), MakeStreeng(right, Radix())) {
+		terms = append(terms, term)
+		if len(terms) == 5 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(terms, expected(func(l, r int) bool { return true })[:5]) {
+		t.Errorf("Test Fail:\t union break: %v", terms)
+	}
+}
@@ -0,0 +1,238 @@
+package streeng
+
+import (
+	"sort"
+	"sync"
+)
+
+/*
+Snapshot function returns a read-only view of the streeng at
+this point. Nodes are shared with the streeng, and later writes
+copy nodes on path of their words before changing them, so the
+snapshot is never affected. Add and Remove do nothing on the
+snapshot. Terms, tokens and suffix index are not kept in it.
+Snapshot can be read by many goroutines, since state which is
+made on first use is made under a lock of the snapshot
+*/
+func (s *Streeng) Snapshot() *Streeng {
+	if s == nil {
+		return nil
+	}
+	snap := new(Streeng)
+	snap.root = s.root
+	snap.reverseRoot = s.reverseRoot
+	snap.radix = s.radix
+	snap.dawg = s.dawg
+	snap.postings = s.postings
+	snap.plain = s.plain
+	snap.trieCount = s.trieCount
+	snap.words = s.words[:len(s.words):len(s.words)]
+	snap.nodeCount = s.nodeCount
+	snap.reverseCount = s.reverseCount
+	snap.depth = s.depth
+	snap.removed = s.removed
+	snap.rate = s.rate
+	snap.weights = s.weights
+	snap.surfaces = s.surfaces[:len(s.surfaces):len(s.surfaces)]
+	snap.offsets = s.offsets[:len(s.offsets):len(s.offsets)]
+	snap.gen = s.gen
+	snap.snapshot = true
+	snap.shared = true
+	snap.lazy = new(sync.Mutex)
+	if !s.snapshot {
+		s.gen++
+		s.shared = true
+		s.owned = nil
+	}
+	return snap
+}
+
+/*
+Rollback function makes the streeng same as given snapshot.
+Snapshot stays valid, since nodes are copied again before
+they are changed. Terms, tokens and suffix index are cleaned
+*/
+func (s *Streeng) Rollback(snap *Streeng) {
+	if s == nil || snap == nil || s.snapshot {
+		return
+	}
+	gen := s.gen
+	if snap.gen > gen {
+		gen = snap.gen
+	}
+	*s = *snap
+	s.gen = gen + 1
+	s.snapshot = false
+	s.shared = true
+	s.owned = nil
+	s.lazy = nil
+	s.best = nil
+	s.bestRanks = nil
+}
+
+/*
+lockLazy locks state of a snapshot which is made on first use,
+like plain tree and weights of Suggest. It does nothing on a
+streeng, since a streeng is not safe for concurrent use
+*/
+func (s *Streeng) lockLazy() {
+	if s.lazy != nil {
+		s.lazy.Lock()
+	}
+}
+
+func (s *Streeng) unlockLazy() {
+	if s.lazy != nil {
+		s.lazy.Unlock()
+	}
+}
+
+/*
+Diff function compares terms of the streeng with terms of old.
+It returns terms which were added and removed since old, in order.
+Subtrees which are shared by both are not visited
+*/
+func (s *Streeng) Diff(old *Streeng) ([]string, []string) {
+	added, removed := []string{}, []string{}
+	if s == nil || old == nil || s.root == nil || old.root == nil {
+		return added, removed
+	}
+	diffChild(s, old, s.root, old.root, nil, &added, &removed)
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+/*
+diffChild compares node a of s with node b of old,
+both of them are at the end of path
+*/
+func diffChild(s, old *Streeng, a, b *Node, path []rune, added, removed *[]string) {
+	if a == b && s.dawg == old.dawg {
+		return
+	}
+	if len(path) > 0 {
+		if isTerm(s, a) && !isTerm(old, b) {
+			*added = append(*added, string(path))
+		} else if !isTerm(s, a) && isTerm(old, b) {
+			*removed = append(*removed, string(path))
+		}
+	}
+	for k, v := range a.characters {
+		next := append(append(path[:len(path):len(path)], k), v.label...)
+		other := b.characters[k]
+		if other == nil {
+			collectTerms(s, v, next, added)
+		} else if string(v.label) == string(other.label) {
+			diffChild(s, old, v, other, next, added, removed)
+		} else {
+			terms, otherTerms := []string{}, []string{}
+			collectTerms(s, v, next, &terms)
+			otherNext := append(append(path[:len(path):len(path)], k), other.label...)
+			collectTerms(old, other, otherNext, &otherTerms)
+			diffTerms(terms, otherTerms, added, removed)
+		}
+	}
+	for k, v := range b.characters {
+		if a.characters[k] == nil {
+			next := append(append(path[:len(path):len(path)], k), v.label...)
+			collectTerms(old, v, next, removed)
+		}
+	}
+}
+
+// diffTerms appends terms which are in only one of sorted terms
+func diffTerms(terms, otherTerms []string, added, removed *[]string) {
+	i, j := 0, 0
+	for i < len(terms) || j < len(otherTerms) {
+		switch {
+		case j == len(otherTerms) || i < len(terms) && terms[i] < otherTerms[j]:
+			*added = append(*added, terms[i])
+			i++
+		case i == len(terms) || terms[i] > otherTerms[j]:
+			*removed = append(*removed, otherTerms[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+}
+
+// collectTerms appends terms under node to terms in order
+func collectTerms(s *Streeng, node *Node, path []rune, terms *[]string) {
+	if isTerm(s, node) {
+		*terms = append(*terms, string(path))
+	}
+	for _, k := range sortedRunes(node) {
+		v := node.characters[k]
+		next := append(append(path[:len(path):len(path)], k), v.label...)
+		collectTerms(s, v, next, terms)
+	}
+}
+
+/*
+ownPaths makes nodes on path of runes in forward and reverse
+trees owned by the streeng, so they can be changed in place.
+Before a write, nodes which are shared with snapshots are copied.
+After a write, new nodes are marked. Owned nodes are kept in a set
+of the streeng, and the set is emptied by Snapshot
+*/
+func (s *Streeng) ownPaths(runic []rune, mark bool) {
+	if s.gen == 0 {
+		return
+	}
+	if s.owned == nil {
+		s.owned = make(map[*Node]bool)
+	}
+	s.root = ownPath(s, s.root, runic, mark)
+	if s.reverseRoot != nil {
+		s.reverseRoot = ownPath(s, s.reverseRoot, reverseRunes(runic), mark)
+	}
+}
+
+// ownPath copies or marks nodes on path of runes and returns root
+func ownPath(s *Streeng, root *Node, runic []rune, mark bool) *Node {
+	own := func(node *Node) *Node {
+		if mark {
+			s.owned[node] = true
+		} else if !s.owned[node] {
+			node = copyNode(node)
+			s.owned[node] = true
+		}
+		return node
+	}
+	root = own(root)
+	tempNode := root
+	lenOfValue := len(runic)
+	for i := 0; i < lenOfValue; {
+		child := tempNode.characters[runic[i]]
+		if child == nil {
+			break
+		}
+		child = own(child)
+		tempNode.characters[runic[i]] = child
+		i++
+		for _, v := range child.label {
+			if i == lenOfValue || v != runic[i] {
+				return root
+			}
+			i++
+		}
+		tempNode = child
+	}
+	return root
+}
+
+// copyNode copies node with its children map and words
+func copyNode(node *Node) *Node {
+	n := new(Node)
+	*n = *node
+	n.label = node.label[:len(node.label):len(node.label)]
+	n.words = append([]int(nil), node.words...)
+	n.characters = make(map[rune]*Node, len(node.characters))
+	for k, v := range node.characters {
+		n.characters[k] = v
+	}
+	return n
+}
@@ -0,0 +1,164 @@
+package streeng
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func liveTerms(words []string, removed map[int]bool) map[string]bool {
+	terms := make(map[string]bool)
+	for k, v := range words {
+		if !removed[k] && len(v) > 0 {
+			terms[v] = true
+		}
+	}
+	return terms
+}
+
+func TestSnapshot(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	tests := []string{`Darcy`, `the`, `Mr`, `disc`, `ness`, `pride`, `asd`}
+	for _, opt := range []string{"trie", "radix"} {
+		opts := []Option{}
+		if opt == "radix" {
+			opts = append(opts, Radix())
+		}
+		streeng := MakeStreeng(words[:10000], opts...)
+		streeng.ReverseStreeng()
+		fresh := MakeStreeng(words[:10000], opts...)
+		fresh.ReverseStreeng()
+		snap := streeng.Snapshot()
+		count := 2000
+		removed := make(map[int]bool)
+		for k, word := range words[10000 : 10000+count] {
+			streeng.Add(word)
+			streeng.Remove(k * 3)
+			removed[k*3] = true
+		}
+		second := streeng.Snapshot()
+		for _, test := range tests {
+			if !reflect.DeepEqual(snap.Search(test), fresh.Search(test)) ||
+				!reflect.DeepEqual(snap.StartWith(test), fresh.StartWith(test)) ||
+				len(snap.EndWith(test)) != len(fresh.EndWith(test)) {
+				t.Errorf("Test Fail:\t %s \t snapshot is changed: %s", opt, test)
+			} else {
+				t.Logf("Test Successful: %s \t word: %s", opt, test)
+			}
+			expected := 0
+			for k, word := range words[:10000+count] {
+				if !removed[k] && word == test {
+					expected++
+				}
+			}
+			if len(streeng.Search(test)) != expected {
+				t.Errorf("Test Fail:\t %s \t word: %s \t expected: %d \t result: %d",
+					opt, test, expected, len(streeng.Search(test)))
+			}
+		}
+		if snap.Add("asd") != -1 || snap.Contains("asd") || snap.Words(0) != words[0] {
+			t.Errorf("Test Fail:\t %s \t snapshot is not read-only", opt)
+		}
+		before := liveTerms(words[:10000], nil)
+		after := liveTerms(words[:10000+count], removed)
+		expectedAdded, expectedRemoved := []string{}, []string{}
+		for k := range after {
+			if !before[k] {
+				expectedAdded = append(expectedAdded, k)
+			}
+		}
+		for k := range before {
+			if !after[k] {
+				expectedRemoved = append(expectedRemoved, k)
+			}
+		}
+		sort.Strings(expectedAdded)
+		sort.Strings(expectedRemoved)
+		added, deleted := second.Diff(snap)
+		if !reflect.DeepEqual(added, expectedAdded) || !reflect.DeepEqual(deleted, expectedRemoved) {
+			t.Errorf("Test Fail:\t %s \t diff: %d %d \t expected: %d %d",
+				opt, len(added), len(deleted), len(expectedAdded), len(expectedRemoved))
+		}
+		added, deleted = snap.Diff(second)
+		if !reflect.DeepEqual(added, expectedRemoved) || !reflect.DeepEqual(deleted, expectedAdded) {
+			t.Errorf("Test Fail:\t %s \t reverse diff: %d %d", opt, len(added), len(deleted))
+		}
+		if !reflect.DeepEqual(snap.Suggest("th", 3), fresh.Suggest("th", 3)) || len(snap.Suggest("th", 3)) != 3 {
+			t.Errorf("Test Fail:\t %s \t suggest on snapshot: %v", opt, snap.Suggest("th", 3))
+		}
+		secondResults := [][]int{}
+		for _, test := range tests {
+			secondResults = append(secondResults, second.StartWith(test))
+		}
+		streeng.Rollback(snap)
+		streeng.Add(words[20000])
+		streeng.Remove(1)
+		for k, test := range tests {
+			if !reflect.DeepEqual(snap.Search(test), fresh.Search(test)) {
+				t.Errorf("Test Fail:\t %s \t snapshot is changed after rollback: %s", opt, test)
+			}
+			if !reflect.DeepEqual(second.StartWith(test), secondResults[k]) {
+				t.Errorf("Test Fail:\t %s \t second snapshot is changed: %s", opt, test)
+			}
+		}
+		if !reflect.DeepEqual(snap.Suggest("th", 3), fresh.Suggest("th", 3)) {
+			t.Errorf("Test Fail:\t %s \t suggest on snapshot after rollback", opt)
+		}
+		if len(streeng.words) != 10001 || streeng.Words(1) != "" || streeng.Words(3) != words[3] {
+			t.Errorf("Test Fail:\t %s \t rollback: %d", opt, len(streeng.words))
+		}
+		if !reflect.DeepEqual(streeng.Suggest("th", 3), MakeStreeng(words[:10000]).Suggest("th", 3)) {
+			t.Errorf("Test Fail:\t %s \t suggest after rollback", opt)
+		}
+	}
+}
+
+func TestSnapshotRace(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words[:5000], Radix())
+	streeng.ReverseStreeng()
+	snap := streeng.Snapshot()
+	dawg := MakeStreeng(words[:5000], DAWG()).Snapshot()
+	expected := len(snap.StartWith("th"))
+	suggestions := MakeStreeng(words[:5000]).Suggest("th", 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				if len(snap.StartWith("th")) != expected {
+					t.Errorf("Test Fail:\t snapshot is changed")
+					return
+				}
+				if !reflect.DeepEqual(snap.Suggest("th", 2), suggestions) ||
+					!reflect.DeepEqual(dawg.Suggest("th", 2), suggestions) {
+					t.Errorf("Test Fail:\t suggest on snapshot")
+					return
+				}
+				snap.EndWith("ness")
+				snap.Rank("the")
+				dawg.Match("th.*")
+				dawg.Query("*ness")
+			}
+		}()
+	}
+	for k, word := range words[5000:7000] {
+		streeng.Add(word)
+		streeng.Remove(k)
+		if k%500 == 0 {
+			streeng.Snapshot()
+		}
+	}
+	wg.Wait()
+}
@@ -1,32 +1,55 @@
 package streeng
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"path/filepath"
-	"regexp"
 	"sync"
 )
 
 // Node is a struct of Streeng node
 type Node struct {
 	value       rune
+	label       []rune
 	words       []int
 	numberWords int
 	characters  map[rune]*Node
+	final       bool
+	size        int
 }
 
 // Streeng is a struct of Streeng
 type Streeng struct {
 	root         *Node
 	reverseRoot  *Node
+	radix        bool
+	dawg         bool
+	postings     [][]int
+	plain        *Node
+	trieCount    int
 	words        []string
 	nodeCount    int
 	reverseCount int
 	depth        int
 	terms        map[string]int
 	tokens       []int
+	lastToken    int
+	removed      map[int]bool
 	rate         float64
+	suffixTerms  [][]rune
+	suffixIDs    map[string]int
+	suffixes     []suffix
+	weights      map[string]int
+	best         map[*Node]int
+	bestRanks    []int
+	surfaces     []string
+	offsets      []int
+	gen          int
+	owned        map[*Node]bool
+	snapshot     bool
+	shared       bool
+	lazy         *sync.Mutex
 }
 
 /*
@@ -69,19 +92,26 @@ func StringFromURL(url string) (string, bool) {
 MakeStreeng makes a streeng struct with given string array
 and it builds new tree. But reverse tree will not build
 */
-func MakeStreeng(words []string) *Streeng {
+func MakeStreeng(words []string, opts ...Option) *Streeng {
+	o := makeOptions(opts)
 	root := new(Node)
 	root.characters = make(map[rune]*Node)
 	root.words = nil
 	s := new(Streeng)
 	s.root = root
+	s.radix = o.radix
+	s.dawg = o.dawg
 	s.nodeCount = 1
 	s.depth = 0
-	for k, v := range words {
-		addString(s, k, v)
+	s.words = words[:len(words):len(words)]
+	if s.dawg {
+		buildDAWG(s)
+	} else {
+		for k, v := range words {
+			addString(s, k, v)
+		}
 	}
 	s.reverseCount = -1
-	s.words = words
 	s.rate = float64(len(words)) / float64(s.nodeCount)
 	s.terms = nil
 	s.tokens = nil
@@ -91,152 +121,238 @@ func MakeStreeng(words []string) *Streeng {
 
 // ReverseStreeng makes reverse tree and attach streeng
 func (s *Streeng) ReverseStreeng() *Node {
-	reverseRoot := new(Node)
-	reverseRoot.characters = make(map[rune]*Node)
-	reverseRoot.words = nil
-	tempNode := reverseRoot
-	count := 0
-	for k, v := range s.words {
-		tempNode = reverseRoot
-		runic := []rune(v)
-		lenOfValue := len(runic)
-		for i := (lenOfValue - 1); i >= 0; i-- {
-			isLast := i == 0
-			if val, ok := tempNode.characters[runic[i]]; ok {
-				tempNode = val
-				if isLast {
-					tempNode.words = append(tempNode.words, k)
-				}
-			} else {
-				count++
-				n := new(Node)
-				n.characters = make(map[rune]*Node)
-				n.value = runic[i]
-				if isLast {
-					n.words = append(n.words, k)
-				}
-				tempNode.characters[runic[i]] = n
-				tempNode = tempNode.characters[runic[i]]
-			}
-		}
-	}
+	reverseRoot, count := makeTree(s, true, s.radix)
 	s.reverseRoot = reverseRoot
 	s.reverseCount = count
 	return reverseRoot
 }
 
-// Traverse function traverses nodes on given tree
+/*
+Traverse function traverses nodes on given tree. Nodes of
+word graphs do not keep words, so a plain tree is made for them once
+*/
 func (s *Streeng) Traverse(sc func(*Node)) {
 	if s != nil && s.root != nil {
-		traverseChild(s.root, sc)
+		traverseChild(plainRoot(s), sc)
 	}
 }
 
-// GoTraverse function traverses nodes on given tree with goroutines
+/*
+GoTraverse function traverses nodes on given tree with goroutines.
+Tree is split by sizes of subtrees to workers like GoTraverseContext
+*/
 func (s *Streeng) GoTraverse(sc func(*Node)) {
-	if s != nil && s.root != nil {
-		var wg sync.WaitGroup
-		wg.Add(len(s.root.characters))
-		for _, v := range s.root.characters {
-			go goTraverseChild(&wg, v, sc)
-		}
-		wg.Wait()
-	}
+	s.GoTraverseContext(context.Background(), 0, func(node *Node) error {
+		sc(node)
+		return nil
+	})
 }
 
-// Clean function cleans the tree
+/*
+Clean function cleans the tree. Streeng is empty after it,
+so words can be added again
+*/
 func (s *Streeng) Clean() {
-	if s != nil && s.root != nil {
-		cleanChild(s.root)
-		cleanChild(s.reverseRoot)
-		s.root.words = nil
-		s.root.characters = nil
+	if s != nil && s.root != nil && !s.snapshot {
+		if s.gen == 0 {
+			cleanChild(s.root)
+			cleanChild(s.reverseRoot)
+		}
+		s.root = new(Node)
+		s.root.characters = make(map[rune]*Node)
+		s.owned = nil
+		s.reverseRoot = nil
 		s.words = nil
 		s.nodeCount = 1
-		s.reverseCount = 1
+		s.reverseCount = -1
+		s.depth = 0
+		s.rate = 0
+		s.lastToken = 0
+		s.trieCount = 0
 		s.terms = nil
 		s.tokens = nil
+		s.removed = nil
+		s.suffixTerms = nil
+		s.suffixIDs = nil
+		s.suffixes = nil
+		s.postings = nil
+		s.plain = nil
+		s.best = nil
+		s.bestRanks = nil
+		s.surfaces = nil
+		s.offsets = nil
+	}
+}
+
+/*
+Add function adds given word to the end of words and returns its index.
+Reverse tree, terms and tokens are updated if they were built.
+Word graph of DAWG option is read-only like a snapshot, so
+Add does nothing and returns -1 on it
+*/
+func (s *Streeng) Add(word string) int {
+	if s.snapshot || s.dawg {
+		return -1
+	}
+	index := len(s.words)
+	s.words = append(s.words, word)
+	runic := []rune(word)
+	s.ownPaths(runic, false)
+	addString(s, index, word)
+	if s.reverseRoot != nil {
+		s.reverseCount += insertRunes(s, s.reverseRoot, reverseRunes(runic), index)
+	}
+	s.ownPaths(runic, true)
+	if s.terms != nil {
+		addTerm(s, index, word)
+	}
+	if s.suffixes != nil && len(word) > 0 {
+		addSuffixes(s, word)
+	}
+	if s.best != nil {
+		rankPath(s, []rune(word))
+	}
+	if s.surfaces != nil {
+		s.surfaces = append(s.surfaces, word)
+		s.offsets = append(s.offsets, -1)
+	}
+	s.rate = float64(len(s.words)-len(s.removed)) / float64(s.nodeCount)
+	return index
+}
+
+/*
+Remove function removes word of given index from the tree.
+Nodes which no longer lead to any word are pruned. Index of
+the word is not reused, so other indexes stay the same.
+Remove does nothing on DAWG option like Add
+*/
+func (s *Streeng) Remove(index int) {
+	if s == nil || s.root == nil || s.snapshot || s.dawg || index < 0 || index >= len(s.words) {
+		return
+	}
+	word := s.words[index]
+	runic := []rune(word)
+	s.ownPaths(runic, false)
+	ok, pruned := deleteRunes(s, s.root, runic, index)
+	if !ok {
+		return
+	}
+	s.nodeCount -= pruned
+	if s.removed == nil || s.shared {
+		removed := make(map[int]bool, len(s.removed)+1)
+		for k := range s.removed {
+			removed[k] = true
+		}
+		s.removed = removed
+		s.shared = false
+	}
+	s.removed[index] = true
+	if s.suffixes != nil && len(word) > 0 && len(s.Search(word)) == 0 {
+		removeSuffixes(s, word)
 	}
+	if s.reverseRoot != nil {
+		_, pruned := deleteRunes(s, s.reverseRoot, reverseRunes(runic), index)
+		s.reverseCount -= pruned
+	}
+	if s.terms != nil {
+		s.terms[word]--
+		if s.terms[word] <= 0 {
+			delete(s.terms, word)
+		}
+		s.tokens[index] = -1
+	}
+	if s.best != nil {
+		rankPath(s, runic)
+	}
+	if len(runic) == s.depth {
+		s.depth = treeDepth(s.root)
+	}
+	s.rate = float64(len(s.words)-len(s.removed)) / float64(s.nodeCount)
 }
 
-// Search function searches given word in the tree
-func (s *Streeng) Search(word string) []int {
+/*
+Search function searches given word in the tree.
+If fold flags are given, words which are equivalent
+under them are searched too
+*/
+func (s *Streeng) Search(word string, fold ...Fold) []int {
 	runic := []rune(word)
 	lenOfWord := len(runic)
 	if s != nil && s.root != nil && lenOfWord > 0 {
-		tempNode := s.root
-		for i := 0; i < lenOfWord; i++ {
-			if tempNode.characters[runic[i]] != nil {
-				tempNode = tempNode.characters[runic[i]]
-			} else {
-				return nil
+		if flags := foldFlags(fold); flags != 0 {
+			cursors, paths := foldSearch(s.root, runic, flags, false, true)
+			if s.dawg {
+				return dawgFoldWords(s, cursors, paths, true)
 			}
+			return foldSearchWords(cursors)
+		}
+		if s.dawg {
+			return dawgSearch(s, runic)
+		}
+		if tempNode, exact := descend(s.root, runic); exact {
+			return tempNode.words
 		}
-		return tempNode.words
 	}
 	return nil
 }
 
-// Match function matches words with given regular expression
+/*
+Match function matches words with given regular expression.
+Regular expression runs alongside the tree, so subtrees which
+can not match are not visited. Tree is split to workers
+like GoTraverseContext
+*/
 func (s *Streeng) Match(regex string) ([]int, error) {
-	re, err := regexp.Compile(regex)
-	if err != nil {
-		return nil, err
-	}
-	var mutex sync.Mutex
-	results := []int{}
-	s.GoTraverse(func(node *Node) {
-		if re.MatchString(s.words[node.words[0]]) {
-			for _, index := range node.words {
-				mutex.Lock()
-				results = append(results, index)
-				mutex.Unlock()
-			}
-		}
-	})
-	return results, nil
+	return s.MatchContext(context.Background(), regex)
 }
 
-// StartWith function searches words which start with given string
-func (s *Streeng) StartWith(word string) []int {
+/*
+StartWith function searches words which start with given string.
+If fold flags are given, equivalent prefixes are searched too
+*/
+func (s *Streeng) StartWith(word string, fold ...Fold) []int {
 	runic := []rune(word)
 	lenOfWord := len(runic)
 	if s != nil && s.root != nil && lenOfWord > 0 {
-		tempNode := s.root
-		for i := 0; i < lenOfWord; i++ {
-			if tempNode.characters[runic[i]] != nil {
-				tempNode = tempNode.characters[runic[i]]
-			} else {
-				return nil
+		if flags := foldFlags(fold); flags != 0 {
+			cursors, paths := foldSearch(s.root, runic, flags, false, false)
+			if s.dawg {
+				return dawgFoldWords(s, cursors, paths, false)
 			}
+			return foldSubstring(cursors)
 		}
-		words := []int{}
-		for _, v := range tempNode.words {
-			words = append(words, v)
+		if s.dawg {
+			return dawgStartWith(s, runic)
+		}
+		tempNode, _ := descend(s.root, runic)
+		if tempNode == nil {
+			return nil
 		}
+		words := []int{}
 		getSubstring(&words, tempNode)
 		return words
 	}
 	return nil
 }
 
-// EndWith function searches words which end with given string
-func (s *Streeng) EndWith(word string) []int {
+/*
+EndWith function searches words which end with given string.
+If fold flags are given, equivalent suffixes are searched too
+*/
+func (s *Streeng) EndWith(word string, fold ...Fold) []int {
 	runic := []rune(word)
 	lenOfWord := len(runic)
 	if s != nil && s.reverseRoot != nil && lenOfWord > 0 {
-		tempNode := s.reverseRoot
-		for i := (lenOfWord - 1); i >= 0; i-- {
-			if tempNode.characters[runic[i]] != nil {
-				tempNode = tempNode.characters[runic[i]]
-			} else {
-				return nil
-			}
+		if flags := foldFlags(fold); flags != 0 {
+			runic = reverseRunes(runic)
+			cursors, _ := foldSearch(s.reverseRoot, runic, flags, true, false)
+			return foldSubstring(cursors)
 		}
-		words := []int{}
-		for _, v := range tempNode.words {
-			words = append(words, v)
+		tempNode, _ := descend(s.reverseRoot, reverseRunes(runic))
+		if tempNode == nil {
+			return nil
 		}
+		words := []int{}
 		getSubstring(&words, tempNode)
 		return words
 	}
@@ -252,7 +368,19 @@ func (s *Streeng) Terms() map[string]int {
 			s.tokens[j] = -1
 		}
 		i := 1
-		collectTerm(s, s.root, &i)
+		if s.dawg {
+			eachTerm(s, func(term string, words []int) bool {
+				s.terms[term] = len(words)
+				for _, v := range words {
+					s.tokens[v] = i
+				}
+				i++
+				return true
+			})
+		} else {
+			collectTerm(s, s.root, &i)
+		}
+		s.lastToken = i - 1
 	}
 	return s.terms
 }
@@ -274,20 +402,23 @@ func (s *Streeng) FindFreqTerms(min int) map[string]int {
 	return nil
 }
 
-// Contains returns whether or not the word exists
-func (s *Streeng) Contains(word string) bool {
+/*
+Contains returns whether or not the word exists.
+If fold flags are given, equivalent words are searched too
+*/
+func (s *Streeng) Contains(word string, fold ...Fold) bool {
 	runic := []rune(word)
 	lenOfWord := len(runic)
 	if s != nil && s.root != nil && lenOfWord > 0 {
-		tempNode := s.root
-		for i := 0; i < lenOfWord; i++ {
-			if tempNode.characters[runic[i]] != nil {
-				tempNode = tempNode.characters[runic[i]]
-			} else {
-				return false
-			}
+		if flags := foldFlags(fold); flags != 0 {
+			return len(s.Search(word, flags)) > 0
+		}
+		if s.dawg {
+			_, tempNode := dawgRank(s.root, runic)
+			return tempNode != nil && tempNode.final
 		}
-		return len(tempNode.words) > 0
+		tempNode, exact := descend(s.root, runic)
+		return exact && len(tempNode.words) > 0
 	}
 	return false
 }
@@ -297,9 +428,12 @@ func (s *Streeng) Depth() int {
 	return s.depth
 }
 
-// Words returns element of words
+/*
+Words returns element of words,
+if word of index was removed, it returns empty string
+*/
 func (s *Streeng) Words(index int) string {
-	if index >= 0 {
+	if index >= 0 && !s.removed[index] {
 		return s.words[index]
 	}
 	return ""
@@ -320,6 +454,18 @@ func (s *Streeng) Rate() float64 {
 	return s.rate
 }
 
+/*
+MinimizationRate returns rate of node count of trie to node
+count of minimal word graph. It returns 1 if streeng was not
+built with DAWG option
+*/
+func (s *Streeng) MinimizationRate() float64 {
+	if s.dawg {
+		return float64(s.trieCount) / float64(s.nodeCount)
+	}
+	return 1
+}
+
 // TermList returns list of terms
 func (s *Streeng) TermList() map[string]int {
 	return s.terms
@@ -335,6 +481,14 @@ func (n *Node) Value() rune {
 	return n.value
 }
 
+/*
+Label returns runes of edge which ends at node.
+It is rune value of node, unless tree is a radix tree
+*/
+func (n *Node) Label() string {
+	return string(n.value) + string(n.label)
+}
+
 /*
 Words returns word of index,
 if index is smaller than 0 or
@@ -368,19 +522,71 @@ func getSubstring(words *[]int, node *Node) {
 		for _, v := range node.words {
 			*words = append(*words, v)
 		}
-		for _, v := range node.characters {
+		for _, v := range sortedChildren(node) {
 			getSubstring(words, v)
 		}
 	}
 }
 
 func addString(s *Streeng, index int, value string) {
-	tempNode := s.root
 	runic := []rune(value)
+	if len(runic) > s.depth {
+		s.depth = len(runic)
+	}
+	s.nodeCount += insertRunes(s, s.root, runic, index)
+}
+
+// insertRunes inserts runes to tree of streeng's kind
+func insertRunes(s *Streeng, root *Node, runic []rune, index int) int {
+	if s.radix {
+		return addRadixRunes(root, runic, index)
+	}
+	return addRunes(root, runic, index)
+}
+
+// deleteRunes removes runes from tree of streeng's kind
+func deleteRunes(s *Streeng, root *Node, runic []rune, index int) (bool, int) {
+	if s.radix {
+		return removeRadixRunes(root, runic, index)
+	}
+	return removeRunes(root, runic, index)
+}
+
+/*
+descend walks runes from root. It returns the node whose edge
+ends at or after the last rune and whether runes end exactly
+at the node. If runes are not in the tree, it returns nil
+*/
+func descend(root *Node, runic []rune) (*Node, bool) {
+	tempNode := root
 	lenOfValue := len(runic)
-	if lenOfValue > s.depth {
-		s.depth = lenOfValue
+	for i := 0; i < lenOfValue; {
+		child := tempNode.characters[runic[i]]
+		if child == nil {
+			return nil, false
+		}
+		i++
+		for _, v := range child.label {
+			if i == lenOfValue {
+				return child, false
+			}
+			if v != runic[i] {
+				return nil, false
+			}
+			i++
+		}
+		tempNode = child
 	}
+	return tempNode, true
+}
+
+// addRunes inserts runes under root and returns count of new nodes
+func addRunes(root *Node, runic []rune, index int) int {
+	tempNode := root
+	count := 0
+	lenOfValue := len(runic)
+	path := make([]*Node, 0, lenOfValue+1)
+	path = append(path, root)
 	for i := 0; i < lenOfValue; i++ {
 		isLast := i+1 == lenOfValue
 		if val, ok := tempNode.characters[runic[i]]; ok {
@@ -394,7 +600,7 @@ func addString(s *Streeng, index int, value string) {
 			n.characters = make(map[rune]*Node)
 			n.value = runic[i]
 			n.numberWords = 0
-			s.nodeCount++
+			count++
 			if isLast {
 				n.words = append(n.words, index)
 				n.numberWords++
@@ -402,7 +608,133 @@ func addString(s *Streeng, index int, value string) {
 			tempNode.characters[runic[i]] = n
 			tempNode = tempNode.characters[runic[i]]
 		}
+		path = append(path, tempNode)
+	}
+	if lenOfValue > 0 && len(tempNode.words) == 1 {
+		addSize(path, 1)
+	}
+	return count
+}
+
+/*
+removeRunes removes index from the node of runes and prunes
+nodes which have no words and no children. It returns whether
+index was found and count of pruned nodes
+*/
+func removeRunes(root *Node, runic []rune, index int) (bool, int) {
+	lenOfValue := len(runic)
+	if lenOfValue == 0 {
+		return false, 0
+	}
+	path := make([]*Node, lenOfValue+1)
+	path[0] = root
+	for i := 0; i < lenOfValue; i++ {
+		val, ok := path[i].characters[runic[i]]
+		if !ok {
+			return false, 0
+		}
+		path[i+1] = val
+	}
+	tempNode := path[lenOfValue]
+	found := -1
+	for k, v := range tempNode.words {
+		if v == index {
+			found = k
+			break
+		}
+	}
+	if found < 0 {
+		return false, 0
+	}
+	tempNode.words = append(tempNode.words[:found], tempNode.words[found+1:]...)
+	tempNode.numberWords--
+	if len(tempNode.words) == 0 {
+		addSize(path, -1)
+	}
+	pruned := 0
+	for i := lenOfValue; i > 0; i-- {
+		if len(path[i].words) > 0 || len(path[i].characters) > 0 {
+			break
+		}
+		delete(path[i-1].characters, runic[i-1])
+		pruned++
+	}
+	return true, pruned
+}
+
+// addTerm updates terms and tokens for added word
+func addTerm(s *Streeng, index int, word string) {
+	if len(word) == 0 {
+		s.tokens = append(s.tokens, -1)
+		return
+	}
+	token := 0
+	if s.terms[word] > 0 {
+		token = s.tokens[s.Search(word)[0]]
+	} else {
+		s.lastToken++
+		token = s.lastToken
+	}
+	s.terms[word]++
+	s.tokens = append(s.tokens, token)
+}
+
+/*
+makeTree makes a tree of words which are not removed and returns
+it with count of its nodes except root. If reverse is true,
+runes of words are reversed
+*/
+func makeTree(s *Streeng, reverse, radix bool) (*Node, int) {
+	root := new(Node)
+	root.characters = make(map[rune]*Node)
+	root.words = nil
+	count := 0
+	for k, v := range s.words {
+		if s.removed[k] {
+			continue
+		}
+		runic := []rune(v)
+		if reverse {
+			runic = reverseRunes(runic)
+		}
+		if radix {
+			count += addRadixRunes(root, runic, k)
+		} else {
+			count += addRunes(root, runic, k)
+		}
+	}
+	return root, count
+}
+
+/*
+addSize adds diff to size of nodes on path. Size of a node
+is count of terms which end at the node or under it
+*/
+func addSize(path []*Node, diff int) {
+	for _, v := range path {
+		v.size += diff
+	}
+}
+
+func reverseRunes(runic []rune) []rune {
+	lenOfValue := len(runic)
+	reversed := make([]rune, lenOfValue)
+	for i, v := range runic {
+		reversed[lenOfValue-1-i] = v
+	}
+	return reversed
+}
+
+func treeDepth(node *Node) int {
+	depth := 0
+	if node != nil {
+		for _, v := range node.characters {
+			if d := treeDepth(v) + 1 + len(v.label); d > depth {
+				depth = d
+			}
+		}
 	}
+	return depth
 }
 
 func collectTerm(s *Streeng, node *Node, i *int) {
@@ -414,7 +746,7 @@ func collectTerm(s *Streeng, node *Node, i *int) {
 			}
 			*i++
 		}
-		for _, v := range node.characters {
+		for _, v := range sortedChildren(node) {
 			collectTerm(s, v, i)
 		}
 	}
@@ -425,22 +757,10 @@ func traverseChild(node *Node, sc func(*Node)) {
 		if len(node.words) > 0 {
 			sc(node)
 		}
-		for _, v := range node.characters {
-			traverseChild(v, sc)
-		}
-	}
-}
-
-func goTraverseChild(wg *sync.WaitGroup, node *Node, sc func(*Node)) {
-	if node != nil {
-		if len(node.words) > 0 {
-			sc(node)
-		}
-		for _, v := range node.characters {
+		for _, v := range sortedChildren(node) {
 			traverseChild(v, sc)
 		}
 	}
-	wg.Done()
 }
 
 func cleanChild(node *Node) {
@@ -450,5 +770,6 @@ func cleanChild(node *Node) {
 		}
 		node.words = nil
 		node.characters = nil
+		node.size = 0
 	}
 }
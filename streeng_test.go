@@ -2,6 +2,7 @@ package streeng
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
@@ -334,3 +335,83 @@ func TestPerformanceSearch(t *testing.T) {
 	duration := end.Sub(start).Nanoseconds() / 1000000
 	fmt.Printf("Find Duration of Streeng:\t %dms\n", duration)
 }
+
+func TestAddRemove(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words[:1000])
+	streeng.ReverseStreeng()
+	streeng.Terms()
+	for _, word := range words[1000:2000] {
+		streeng.Add(word)
+	}
+	fresh := MakeStreeng(words[:2000])
+	fresh.ReverseStreeng()
+	if streeng.NodeCount() != fresh.NodeCount() ||
+		streeng.ReverseNodeCount() != fresh.ReverseNodeCount() ||
+		streeng.Depth() != fresh.Depth() {
+		t.Errorf("Test Fail:\t add: %d %d %d \t expected: %d %d %d",
+			streeng.NodeCount(), streeng.ReverseNodeCount(), streeng.Depth(),
+			fresh.NodeCount(), fresh.ReverseNodeCount(), fresh.Depth())
+	}
+	for k, v := range streeng.TokenList() {
+		if v == -1 {
+			t.Errorf("Test Fail:\t %d of token is empty", k)
+			break
+		}
+	}
+	for i := 1000; i < 2000; i++ {
+		streeng.Remove(i)
+	}
+	fresh = MakeStreeng(words[:1000])
+	fresh.ReverseStreeng()
+	fresh.Terms()
+	if streeng.NodeCount() != fresh.NodeCount() ||
+		streeng.ReverseNodeCount() != fresh.ReverseNodeCount() ||
+		streeng.Depth() != fresh.Depth() ||
+		len(streeng.TermList()) != len(fresh.TermList()) {
+		t.Errorf("Test Fail:\t remove: %d %d %d \t expected: %d %d %d",
+			streeng.NodeCount(), streeng.ReverseNodeCount(), streeng.Depth(),
+			fresh.NodeCount(), fresh.ReverseNodeCount(), fresh.Depth())
+	}
+	for k, v := range fresh.TermList() {
+		if streeng.TermList()[k] != v || len(streeng.EndWith(k)) < v {
+			t.Errorf("Test Fail:\t term: %s \t expected: %d \t result: %d",
+				k, v, streeng.TermList()[k])
+		}
+	}
+	if streeng.Words(1500) != "" || streeng.Contains(words[1999]) != fresh.Contains(words[1999]) {
+		t.Errorf("Test Fail:\t removed word: %s", words[1999])
+	}
+}
+
+func TestCleanAdd(t *testing.T) {
+	for _, opts := range [][]Option{nil, {Radix()}, {DAWG()}} {
+		streeng := MakeStreeng([]string{"a", "bird", "a"}, opts...)
+		streeng.ReverseStreeng()
+		streeng.Terms()
+		streeng.Clean()
+		if streeng.Depth() != 0 || streeng.Rate() != 0 || streeng.Contains("a") {
+			t.Errorf("Test Fail:\t clean: %d %f", streeng.Depth(), streeng.Rate())
+		}
+		if streeng.dawg {
+			if index := streeng.Add("cat"); index != -1 || streeng.Contains("cat") {
+				t.Errorf("Test Fail:\t add to dawg after clean: %d", index)
+			}
+			continue
+		}
+		if index := streeng.Add("cat"); index != 0 {
+			t.Errorf("Test Fail:\t add after clean: %d", index)
+		}
+		streeng.Add("cart")
+		if !reflect.DeepEqual(streeng.Search("cat"), []int{0}) || len(streeng.StartWith("ca")) != 2 ||
+			streeng.Depth() != 4 || streeng.Words(1) != "cart" {
+			t.Errorf("Test Fail:\t add after clean: %v %d", streeng.Search("cat"), streeng.Depth())
+		} else {
+			t.Logf("Test Successful: add after clean")
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package streeng
+
+import "sort"
+
+// suffix is a suffix of a term in the suffix index
+type suffix struct {
+	term   int
+	offset int
+}
+
+/*
+BuildSuffixIndex function builds a suffix array over
+distinct terms and attach streeng. It returns count of suffixes
+*/
+func (s *Streeng) BuildSuffixIndex() int {
+	if s == nil || s.root == nil {
+		return 0
+	}
+	s.suffixTerms = [][]rune{}
+	s.suffixIDs = make(map[string]int)
+	s.suffixes = []suffix{}
+	eachTerm(s, func(term string, words []int) bool {
+		runic := []rune(term)
+		s.suffixIDs[term] = len(s.suffixTerms)
+		for i := range runic {
+			s.suffixes = append(s.suffixes, suffix{len(s.suffixTerms), i})
+		}
+		s.suffixTerms = append(s.suffixTerms, runic)
+		return true
+	})
+	sort.Slice(s.suffixes, func(i, j int) bool {
+		return compareSuffix(s, s.suffixes[i], suffixRunes(s, s.suffixes[j])) < 0
+	})
+	return len(s.suffixes)
+}
+
+/*
+ContainsSubstring function searches words which contain given
+fragment. Suffix index must be built before calling it
+*/
+func (s *Streeng) ContainsSubstring(fragment string) []int {
+	runic := []rune(fragment)
+	if s == nil || s.suffixes == nil || len(runic) == 0 {
+		return nil
+	}
+	i := sort.Search(len(s.suffixes), func(i int) bool {
+		return compareSuffix(s, s.suffixes[i], runic) >= 0
+	})
+	found := make(map[int]bool)
+	words := []int{}
+	for ; i < len(s.suffixes) && hasSuffixPrefix(s, s.suffixes[i], runic); i++ {
+		term := s.suffixes[i].term
+		if !found[term] {
+			found[term] = true
+			words = append(words, s.Search(string(s.suffixTerms[term]))...)
+		}
+	}
+	return words
+}
+
+// SuffixCount returns count of streeng's suffix index
+func (s *Streeng) SuffixCount() int {
+	return len(s.suffixes)
+}
+
+// addSuffixes inserts suffixes of a new term to the suffix index
+func addSuffixes(s *Streeng, word string) {
+	if _, ok := s.suffixIDs[word]; ok {
+		return
+	}
+	runic := []rune(word)
+	term := len(s.suffixTerms)
+	s.suffixIDs[word] = term
+	s.suffixTerms = append(s.suffixTerms, runic)
+	for k := range runic {
+		i := sort.Search(len(s.suffixes), func(i int) bool {
+			return compareSuffix(s, s.suffixes[i], runic[k:]) >= 0
+		})
+		s.suffixes = append(s.suffixes, suffix{})
+		copy(s.suffixes[i+1:], s.suffixes[i:])
+		s.suffixes[i] = suffix{term, k}
+	}
+}
+
+/*
+removeSuffixes drops suffixes of a term whose last word was
+removed. Id of the term is not reused
+*/
+func removeSuffixes(s *Streeng, word string) {
+	term, ok := s.suffixIDs[word]
+	if !ok {
+		return
+	}
+	suffixes := s.suffixes[:0]
+	for _, v := range s.suffixes {
+		if v.term != term {
+			suffixes = append(suffixes, v)
+		}
+	}
+	s.suffixes = suffixes
+	s.suffixTerms[term] = nil
+	delete(s.suffixIDs, word)
+}
+
+func suffixRunes(s *Streeng, suf suffix) []rune {
+	return s.suffixTerms[suf.term][suf.offset:]
+}
+
+// compareSuffix compares runes of suffix with given runes
+func compareSuffix(s *Streeng, suf suffix, runic []rune) int {
+	value := suffixRunes(s, suf)
+	for i := 0; i < len(value) && i < len(runic); i++ {
+		if value[i] != runic[i] {
+			if value[i] < runic[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(value) - len(runic)
+}
+
+func hasSuffixPrefix(s *Streeng, suf suffix, runic []rune) bool {
+	value := suffixRunes(s, suf)
+	if len(value) < len(runic) {
+		return false
+	}
+	for i := range runic {
+		if value[i] != runic[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package streeng
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContainsSubstring(t *testing.T) {
+	fileName := "pp.txt"
+	tests := []string{
+		`ight`,
+		`scret`,
+		`a`,
+		`ness`,
+		`Mr`,
+		`rs.`,
+		`asdgh`,
+		`http`,
+		`ughte`,
+		``,
+	}
+	text, err := StringFromFile(fileName)
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	streeng := MakeStreeng(words[:len(words)-5000])
+	streeng.BuildSuffixIndex()
+	for _, word := range words[len(words)-5000:] {
+		streeng.Add(word)
+	}
+	for _, test := range tests {
+		i := 0
+		for _, word := range streeng.words {
+			if len(test) > 0 && strings.Contains(word, test) {
+				i++
+			}
+		}
+		results := streeng.ContainsSubstring(test)
+		if len(results) == i {
+			t.Logf("Test Successful: fragment: %s", test)
+		} else {
+			t.Errorf("Test Fail:\t fragment: %s \t expected: %d \t result: %d",
+				test, i, len(results))
+		}
+	}
+}
+
+func TestSuffixIndexRemove(t *testing.T) {
+	streeng := MakeStreeng(strings.Fields("cat cart cat dog"))
+	streeng.BuildSuffixIndex()
+	streeng.Remove(0)
+	if streeng.SuffixCount() != 3+4+3 || len(streeng.ContainsSubstring("at")) != 1 {
+		t.Errorf("Test Fail:\t suffix count: %d", streeng.SuffixCount())
+	}
+	streeng.Remove(2)
+	streeng.Remove(3)
+	fresh := MakeStreeng([]string{"cart"})
+	if streeng.SuffixCount() != fresh.BuildSuffixIndex() ||
+		len(streeng.ContainsSubstring("at")) != 0 || len(streeng.ContainsSubstring("ar")) != 1 {
+		t.Errorf("Test Fail:\t suffix count: %d \t expected: %d", streeng.SuffixCount(), fresh.SuffixCount())
+	}
+	streeng.Add("cat")
+	if streeng.SuffixCount() != 7 || len(streeng.ContainsSubstring("at")) != 1 {
+		t.Errorf("Test Fail:\t suffix count after add: %d", streeng.SuffixCount())
+	} else {
+		t.Logf("Test Successful: suffix index remove")
+	}
+}
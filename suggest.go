@@ -0,0 +1,247 @@
+package streeng
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Suggestion is a struct of a term suggested for a prefix
+type Suggestion struct {
+	Term   string
+	Count  int
+	Weight int
+}
+
+/*
+Suggest function returns k terms which start with given prefix
+and have the biggest weights. Weight of a term is its frequency,
+unless weights were given by SetWeights. Maximum weight of each
+subtree is calculated once and kept by node in the streeng, so
+only nodes which can have one of k terms are visited. On DAWG
+option nodes are shared by many terms, so maximum weights are
+kept by ranges of term ranks instead. Snapshots keep their own
+weights, so they are not changed by writes to the streeng
+*/
+func (s *Streeng) Suggest(prefix string, k int) []Suggestion {
+	if s == nil || s.root == nil || k <= 0 {
+		return nil
+	}
+	s.lockLazy()
+	if s.dawg && s.bestRanks == nil {
+		rankTerms(s)
+	} else if !s.dawg && s.best == nil {
+		s.best = make(map[*Node]int, s.nodeCount)
+		rankChild(s, s.root)
+	}
+	s.unlockLazy()
+	if s.dawg {
+		return dawgSuggest(s, []rune(prefix), k)
+	}
+	tempNode, _ := descend(s.root, []rune(prefix))
+	if tempNode == nil {
+		return nil
+	}
+	queue := &suggestQueue{{node: tempNode, weight: s.best[tempNode]}}
+	suggestions := []Suggestion{}
+	for queue.Len() > 0 && len(suggestions) < k {
+		item := heap.Pop(queue).(suggestItem)
+		if item.term != "" {
+			suggestions = append(suggestions, Suggestion{
+				Term:   item.term,
+				Count:  item.node.numberWords,
+				Weight: item.weight,
+			})
+			continue
+		}
+		if len(item.node.words) > 0 {
+			heap.Push(queue, suggestItem{
+				node:   item.node,
+				weight: nodeWeight(s, item.node),
+				term:   s.words[item.node.words[0]],
+			})
+		}
+		for _, v := range item.node.characters {
+			if best := s.best[v]; best != math.MinInt {
+				heap.Push(queue, suggestItem{node: v, weight: best})
+			}
+		}
+	}
+	return suggestions
+}
+
+/*
+SetWeights function sets weights of terms for Suggest instead
+of frequency. Terms which are not in weights have 0 weight.
+If weights is nil, frequency is used again
+*/
+func (s *Streeng) SetWeights(weights map[string]int) {
+	s.weights = weights
+	s.best = nil
+	s.bestRanks = nil
+}
+
+/*
+dawgSuggest finds terms which start with runic. Their postings
+are a range of ranks, which is split into ranges of the segment
+tree, and only ranges which can have one of k terms are split again
+*/
+func dawgSuggest(s *Streeng, runic []rune, k int) []Suggestion {
+	rank, tempNode := dawgRank(s.root, runic)
+	if tempNode == nil {
+		return nil
+	}
+	n := len(s.bestRanks) / 2
+	queue := &suggestQueue{}
+	push := func(index int) {
+		if best := s.bestRanks[index]; best >= 0 {
+			heap.Push(queue, suggestItem{weight: rankWeight(s, best), term: s.words[s.postings[best][0]], index: index})
+		}
+	}
+	for l, r := rank+n, rank+tempNode.size+n; l < r; l, r = l/2, r/2 {
+		if l%2 == 1 {
+			push(l)
+			l++
+		}
+		if r%2 == 1 {
+			r--
+			push(r)
+		}
+	}
+	suggestions := []Suggestion{}
+	for queue.Len() > 0 && len(suggestions) < k {
+		item := heap.Pop(queue).(suggestItem)
+		if item.index < n {
+			push(2 * item.index)
+			push(2*item.index + 1)
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Term:   item.term,
+			Count:  len(s.postings[item.index-n]),
+			Weight: item.weight,
+		})
+	}
+	return suggestions
+}
+
+/*
+rankTerms makes a segment tree over ranks of terms of word
+graph. Each range keeps the rank of its biggest weight, and the
+smallest rank on equal weights, so terms are ordered like Suggest
+*/
+func rankTerms(s *Streeng) {
+	n := 1
+	for n < len(s.postings) {
+		n *= 2
+	}
+	s.bestRanks = make([]int, 2*n)
+	for i := n; i < 2*n; i++ {
+		s.bestRanks[i] = -1
+		if i-n < len(s.postings) {
+			s.bestRanks[i] = i - n
+		}
+	}
+	for i := n - 1; i > 0; i-- {
+		left, right := s.bestRanks[2*i], s.bestRanks[2*i+1]
+		if right >= 0 && rankWeight(s, right) > rankWeight(s, left) {
+			left = right
+		}
+		s.bestRanks[i] = left
+	}
+}
+
+// rankWeight returns weight of term of given rank in word graph
+func rankWeight(s *Streeng, rank int) int {
+	if s.weights != nil {
+		return s.weights[s.words[s.postings[rank][0]]]
+	}
+	return len(s.postings[rank])
+}
+
+func nodeWeight(s *Streeng, node *Node) int {
+	if len(node.words) == 0 {
+		return math.MinInt
+	}
+	if s.weights != nil {
+		return s.weights[s.words[node.words[0]]]
+	}
+	return node.numberWords
+}
+
+// rankChild calculates maximum weight of subtree of each node
+func rankChild(s *Streeng, node *Node) int {
+	best := nodeWeight(s, node)
+	for _, v := range node.characters {
+		if weight := rankChild(s, v); weight > best {
+			best = weight
+		}
+	}
+	s.best[node] = best
+	return best
+}
+
+// rankPath calculates maximum weights of nodes on path of runes again
+func rankPath(s *Streeng, runic []rune) {
+	path := []*Node{s.root}
+	tempNode := s.root
+	for i := 0; i < len(runic); {
+		child := tempNode.characters[runic[i]]
+		if child == nil {
+			break
+		}
+		i += 1 + len(child.label)
+		path = append(path, child)
+		tempNode = child
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		node := path[i]
+		best := nodeWeight(s, node)
+		for _, v := range node.characters {
+			if s.best[v] > best {
+				best = s.best[v]
+			}
+		}
+		s.best[node] = best
+	}
+}
+
+/*
+suggestItem is a subtree whose weight is its maximum weight,
+or a term if term is not empty. On DAWG option it is a range
+of the segment tree at index, and term is its biggest term
+*/
+type suggestItem struct {
+	node   *Node
+	weight int
+	term   string
+	index  int
+}
+
+/*
+suggestQueue is a max heap of items. On equal weights subtrees
+come first, so terms of equal weights are ordered by term
+*/
+type suggestQueue []suggestItem
+
+func (q suggestQueue) Len() int { return len(q) }
+
+func (q suggestQueue) Less(i, j int) bool {
+	if q[i].weight != q[j].weight {
+		return q[i].weight > q[j].weight
+	}
+	if (q[i].term == "") != (q[j].term == "") {
+		return q[i].term == ""
+	}
+	return q[i].term < q[j].term
+}
+
+func (q suggestQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *suggestQueue) Push(x interface{}) { *q = append(*q, x.(suggestItem)) }
+
+func (q *suggestQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,96 @@
+package streeng
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func expectedSuggest(words []string, weights map[string]int, prefix string, k int) []Suggestion {
+	counts := make(map[string]int)
+	for _, word := range words {
+		if strings.HasPrefix(word, prefix) {
+			counts[word]++
+		}
+	}
+	suggestions := []Suggestion{}
+	for term, count := range counts {
+		weight := count
+		if weights != nil {
+			weight = weights[term]
+		}
+		suggestions = append(suggestions, Suggestion{term, count, weight})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Weight != suggestions[j].Weight {
+			return suggestions[i].Weight > suggestions[j].Weight
+		}
+		return suggestions[i].Term < suggestions[j].Term
+	})
+	if len(suggestions) > k {
+		suggestions = suggestions[:k]
+	}
+	return suggestions
+}
+
+func TestSuggest(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	tests := []string{`th`, `Mr`, `disc`, `w`, `asd`, `pleasure`, ``}
+	for _, radix := range []bool{false, true} {
+		opts := []Option{}
+		if radix {
+			opts = append(opts, Radix())
+		}
+		streeng := MakeStreeng(words[:len(words)-1000], opts...)
+		streeng.Suggest("a", 1)
+		for _, word := range words[len(words)-1000:] {
+			streeng.Add(word)
+		}
+		for i := 0; i < 1000; i++ {
+			streeng.Remove(i)
+		}
+		live := words[1000:]
+		for _, test := range tests {
+			for _, k := range []int{1, 5, 20} {
+				results := streeng.Suggest(test, k)
+				expected := expectedSuggest(live, nil, test, k)
+				if reflect.DeepEqual(results, expected) ||
+					len(results) == 0 && len(expected) == 0 {
+					t.Logf("Test Successful: prefix: %s \t k: %d", test, k)
+				} else {
+					t.Errorf("Test Fail:\t prefix: %s \t k: %d \t expected: %v \t result: %v",
+						test, k, expected, results)
+				}
+			}
+		}
+		weights := map[string]int{"the": 1, "then": 5, "their": 5, "thus": 3}
+		streeng.SetWeights(weights)
+		results := streeng.Suggest("th", 3)
+		expected := expectedSuggest(live, weights, "th", 3)
+		if !reflect.DeepEqual(results, expected) {
+			t.Errorf("Test Fail:\t weights \t expected: %v \t result: %v", expected, results)
+		}
+	}
+	live := words[1000:]
+	dawg := MakeStreeng(live, DAWG())
+	for _, test := range tests {
+		for _, k := range []int{1, 5, 20} {
+			results := dawg.Suggest(test, k)
+			expected := expectedSuggest(live, nil, test, k)
+			if !reflect.DeepEqual(results, expected) && (len(results) > 0 || len(expected) > 0) {
+				t.Errorf("Test Fail:\t dawg \t prefix: %s \t k: %d \t expected: %v \t result: %v",
+					test, k, expected, results)
+			}
+		}
+	}
+	weights := map[string]int{"the": 1, "then": 5, "their": 5, "thus": 3}
+	dawg.SetWeights(weights)
+	if results, expected := dawg.Suggest("th", 3), expectedSuggest(live, weights, "th", 3); !reflect.DeepEqual(results, expected) {
+		t.Errorf("Test Fail:\t dawg weights \t expected: %v \t result: %v", expected, results)
+	}
+}
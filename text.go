@@ -0,0 +1,227 @@
+package streeng
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Token is a struct of a token in text
+type Token struct {
+	Term    string
+	Surface string
+	Offset  int
+}
+
+/*
+Tokenizer is an interface which splits text to tokens.
+Term of each token is its surface, normalization steps
+change terms later
+*/
+type Tokenizer interface {
+	Tokenize(text string) []Token
+}
+
+// FieldsTokenizer splits text around white space like strings.Fields
+type FieldsTokenizer struct{}
+
+/*
+SimpleWordTokenizer splits text to runs of letters, marks, digits
+and connectors. Apostrophes, periods and colons are kept when they
+are between letters or digits, like "don't" and "3.14". It is not
+Unicode word segmentation of UAX #29, so a run of CJK ideographs
+is one word, and emoji and their ZWJ sequences are dropped
+*/
+type SimpleWordTokenizer struct{}
+
+// Tokenize function splits text around white space
+func (FieldsTokenizer) Tokenize(text string) []Token {
+	tokens := []Token{}
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				tokens = append(tokens, Token{text[start:i], text[start:i], start})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{text[start:], text[start:], start})
+	}
+	return tokens
+}
+
+// Tokenize function splits text to runs of word runes
+func (SimpleWordTokenizer) Tokenize(text string) []Token {
+	tokens := []Token{}
+	start := -1
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 && isMidWordRune(r) && i+size < len(text) {
+			next, _ := utf8.DecodeRuneInString(text[i+size:])
+			if !isWordRune(next) {
+				tokens = append(tokens, Token{text[start:i], text[start:i], start})
+				start = -1
+			}
+		} else if start >= 0 {
+			tokens = append(tokens, Token{text[start:i], text[start:i], start})
+			start = -1
+		}
+		i += size
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{text[start:], text[start:], start})
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) ||
+		unicode.Is(unicode.Pc, r)
+}
+
+func isMidWordRune(r rune) bool {
+	return r == '\'' || r == '’' || r == '.' || r == ':'
+}
+
+/*
+WithTokenizer option sets tokenizer of MakeStreengFromText.
+Default tokenizer is FieldsTokenizer
+*/
+func WithTokenizer(t Tokenizer) Option {
+	return func(o *options) {
+		o.tokenizer = t
+	}
+}
+
+// NFC option normalizes terms to Unicode canonical composition
+func NFC() Option {
+	return func(o *options) {
+		o.form = norm.NFC
+		o.normalize = true
+	}
+}
+
+// NFKC option normalizes terms to Unicode compatibility composition
+func NFKC() Option {
+	return func(o *options) {
+		o.form = norm.NFKC
+		o.normalize = true
+	}
+}
+
+// CaseFold option folds case of terms, so "Mrs." and "mrs." are one term
+func CaseFold() Option {
+	return func(o *options) {
+		o.fold = true
+	}
+}
+
+// StripDiacritics option removes diacritics of terms, so "café" is "cafe"
+func StripDiacritics() Option {
+	return func(o *options) {
+		o.strip = true
+	}
+}
+
+// TrimPunctuation option removes punctuation at both ends of terms
+func TrimPunctuation() Option {
+	return func(o *options) {
+		o.trim = true
+	}
+}
+
+/*
+MakeStreengFromText makes a streeng of tokens of text.
+Steps run in this order: tokenizer, normalization, case folding,
+diacritic stripping and punctuation trimming. Tokens whose terms
+become empty are dropped. Surface and byte offset of each token
+are kept, so Surface and Offset return them by word index
+*/
+func MakeStreengFromText(text string, opts ...Option) *Streeng {
+	tokens := textTokens(text, makeOptions(opts))
+	words := make([]string, len(tokens))
+	surfaces := make([]string, len(tokens))
+	offsets := make([]int, len(tokens))
+	for k, v := range tokens {
+		words[k] = v.Term
+		surfaces[k] = v.Surface
+		offsets[k] = v.Offset
+	}
+	s := MakeStreeng(words, opts...)
+	s.surfaces = surfaces
+	s.offsets = offsets
+	return s
+}
+
+/*
+Surface returns original form of word of index in text.
+If streeng was not made from text, it returns word of index
+*/
+func (s *Streeng) Surface(index int) string {
+	if s.surfaces != nil && index >= 0 && index < len(s.surfaces) {
+		return s.surfaces[index]
+	}
+	return s.Words(index)
+}
+
+/*
+Offset returns byte offset of word of index in text.
+If streeng was not made from text or word was added later,
+it returns -1
+*/
+func (s *Streeng) Offset(index int) int {
+	if s.offsets != nil && index >= 0 && index < len(s.offsets) {
+		return s.offsets[index]
+	}
+	return -1
+}
+
+// textTokens tokenizes text and runs normalization steps on terms
+func textTokens(text string, o *options) []Token {
+	tokenizer := o.tokenizer
+	if tokenizer == nil {
+		tokenizer = FieldsTokenizer{}
+	}
+	steps := []transform.Transformer{}
+	if o.normalize {
+		steps = append(steps, o.form)
+	}
+	if o.fold {
+		steps = append(steps, cases.Fold())
+	}
+	if o.strip {
+		steps = append(steps, norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	}
+	var chain transform.Transformer
+	if len(steps) > 0 {
+		chain = transform.Chain(steps...)
+	}
+	tokens := []Token{}
+	for _, v := range tokenizer.Tokenize(text) {
+		if chain != nil {
+			if term, _, err := transform.String(chain, v.Term); err == nil {
+				v.Term = term
+			}
+		}
+		if o.trim {
+			v.Term = strings.TrimFunc(v.Term, unicode.IsPunct)
+		}
+		if len(v.Term) > 0 {
+			tokens = append(tokens, v)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,80 @@
+package streeng
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestMakeStreengFromText(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	streeng := MakeStreengFromText(text, CaseFold(), TrimPunctuation())
+	tests := []string{`bennet`, `mrs`, `elizabeth`, `darcy`, `asd`}
+	for _, test := range tests {
+		i := 0
+		for _, word := range strings.Fields(text) {
+			if strings.ToLower(strings.TrimFunc(word, unicode.IsPunct)) == test {
+				i++
+			}
+		}
+		results := streeng.Search(test)
+		if len(results) == i {
+			t.Logf("Test Successful: word: %s", test)
+		} else {
+			t.Errorf("Test Fail:\t word: %s \t expected: %d \t result: %d",
+				test, i, len(results))
+		}
+		for _, v := range results {
+			surface := streeng.Surface(v)
+			if text[streeng.Offset(v):streeng.Offset(v)+len(surface)] != surface {
+				t.Errorf("Test Fail:\t word: %s \t surface: %s \t offset: %d",
+					test, surface, streeng.Offset(v))
+				break
+			}
+		}
+	}
+	var buffer bytes.Buffer
+	streeng.WriteTo(&buffer)
+	loaded, err := ReadStreeng(&buffer)
+	if err != nil || loaded.Surface(100) != streeng.Surface(100) ||
+		loaded.Offset(100) != streeng.Offset(100) {
+		t.Errorf("Test Fail:\t surfaces are not read: %v", err)
+	}
+}
+
+func TestTextSteps(t *testing.T) {
+	tests := []struct {
+		text  string
+		opts  []Option
+		terms []string
+	}{
+		{"Café CAFE café", []Option{CaseFold(), StripDiacritics()},
+			[]string{"cafe", "cafe", "cafe"}},
+		{"ﬁne Ｆｕｌｌ", []Option{NFKC()}, []string{"fine", "Full"}},
+		{"café", []Option{NFC()}, []string{"café"}},
+		{"\"Bennet,\" said -- Mrs.", []Option{TrimPunctuation()},
+			[]string{"Bennet", "said", "Mrs"}},
+		{"Don't stop, Mr. Darcy—3.14!", []Option{WithTokenizer(SimpleWordTokenizer{})},
+			[]string{"Don't", "stop", "Mr", "Darcy", "3.14"}},
+		{"日本語 👨‍👩‍👧 can't.", []Option{WithTokenizer(SimpleWordTokenizer{})},
+			[]string{"日本語", "can't"}},
+	}
+	for _, test := range tests {
+		streeng := MakeStreengFromText(test.text, test.opts...)
+		terms := []string{}
+		for k := range streeng.words {
+			terms = append(terms, streeng.Words(k))
+		}
+		if reflect.DeepEqual(terms, test.terms) {
+			t.Logf("Test Successful: text: %s", test.text)
+		} else {
+			t.Errorf("Test Fail:\t text: %s \t expected: %q \t result: %q",
+				test.text, test.terms, terms)
+		}
+	}
+}
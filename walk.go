@@ -0,0 +1,52 @@
+package streeng
+
+// WalkAction is a result of Walk function which controls the walk
+type WalkAction int
+
+const (
+	// Continue visits children of node
+	Continue WalkAction = iota
+	// SkipChildren does not visit children of node
+	SkipChildren
+	// Stop ends the walk
+	Stop
+)
+
+/*
+Walk function calls fn for every node of the tree in order of
+runes with path of runes from root to the end of node's edge.
+path is reused, so it should be copied to keep it
+*/
+func (s *Streeng) Walk(fn func(path []rune, n *Node) WalkAction) {
+	if s != nil && s.root != nil {
+		walkChild(plainRoot(s), make([]rune, 0, s.depth), fn)
+	}
+}
+
+/*
+WalkReverse function walks reverse tree like Walk. Runes of path
+are in order of reverse tree, so they are reversed runes of words
+*/
+func (s *Streeng) WalkReverse(fn func(path []rune, n *Node) WalkAction) {
+	if s != nil && s.reverseRoot != nil {
+		walkChild(s.reverseRoot, make([]rune, 0, s.depth), fn)
+	}
+}
+
+// walkChild walks children of node and returns false if walk is stopped
+func walkChild(node *Node, path []rune, fn func(path []rune, n *Node) WalkAction) bool {
+	for _, k := range sortedRunes(node) {
+		v := node.characters[k]
+		next := append(append(path, k), v.label...)
+		switch fn(next, v) {
+		case Stop:
+			return false
+		case SkipChildren:
+			continue
+		}
+		if !walkChild(v, next, fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package streeng
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	text, err := StringFromFile("pp.txt")
+	if err != nil {
+		t.Errorf("Test Fail: StringFromFile Error: %s\n", err.Error())
+	}
+	words := strings.Fields(text)
+	for _, radix := range []bool{false, true} {
+		opts := []Option{}
+		if radix {
+			opts = append(opts, Radix())
+		}
+		streeng := MakeStreeng(words, opts...)
+		streeng.ReverseStreeng()
+		terms := []string{}
+		streeng.Walk(func(path []rune, n *Node) WalkAction {
+			if len(n.words) > 0 {
+				if string(path) != streeng.words[n.words[0]] {
+					t.Errorf("Test Fail:\t radix: %t \t path: %s", radix, string(path))
+				}
+				terms = append(terms, string(path))
+			}
+			return Continue
+		})
+		expected := []string{}
+		for term := range streeng.Terms() {
+			expected = append(expected, term)
+		}
+		sort.Strings(expected)
+		if !reflect.DeepEqual(terms, expected) {
+			t.Errorf("Test Fail:\t radix: %t \t terms: %d \t expected: %d", radix, len(terms), len(expected))
+		}
+		count := 0
+		streeng.WalkReverse(func(path []rune, n *Node) WalkAction {
+			if len(n.words) > 0 {
+				if string(reverseRunes(path)) != streeng.words[n.words[0]] {
+					t.Errorf("Test Fail:\t radix: %t \t reverse path: %s", radix, string(path))
+				}
+				count++
+			}
+			return Continue
+		})
+		if count != len(expected) {
+			t.Errorf("Test Fail:\t radix: %t \t reverse terms: %d", radix, count)
+		}
+		prefixed := []string{}
+		streeng.Walk(func(path []rune, n *Node) WalkAction {
+			if !strings.HasPrefix(string(path), "th") && !strings.HasPrefix("th", string(path)) {
+				return SkipChildren
+			}
+			if len(n.words) > 0 && strings.HasPrefix(string(path), "th") {
+				prefixed = append(prefixed, string(path))
+			}
+			return Continue
+		})
+		count = 0
+		for _, v := range expected {
+			if strings.HasPrefix(v, "th") {
+				count++
+			}
+		}
+		if len(prefixed) != count {
+			t.Errorf("Test Fail:\t radix: %t \t skip: %d \t expected: %d", radix, len(prefixed), count)
+		}
+		visited := 0
+		streeng.Walk(func(path []rune, n *Node) WalkAction {
+			visited++
+			if visited == 10 {
+				return Stop
+			}
+			return Continue
+		})
+		if visited != 10 {
+			t.Errorf("Test Fail:\t radix: %t \t stop: %d", radix, visited)
+		} else {
+			t.Logf("Test Successful: radix: %t \t terms: %d", radix, len(terms))
+		}
+	}
+}